@@ -3,14 +3,14 @@ package alias
 import (
 	"errors"
 	"fmt"
-	"strconv"
+	"time"
 
 	"github.com/0xcfff/hostsctl/commands/common"
 	"github.com/0xcfff/hostsctl/hosts"
 	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/0xcfff/hostsctl/hosts/resolve"
 	"github.com/0xcfff/hostsctl/iptools"
 	"github.com/spf13/cobra"
-	"golang.org/x/exp/slices"
 )
 
 type AliasAddOptions struct {
@@ -18,30 +18,61 @@ type AliasAddOptions struct {
 	blockIdOrName string
 	comment       string
 	force         bool
+	upsert        bool
+	scope         string
+	prune         bool
+	checkConflict bool
+	ttl           time.Duration
+	expires       string
+	expiresAt     time.Time
+	dryRun        common.DryRunOptions
 }
 
+// Layouts accepted by --expires, times without zone are local
+var expiresLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"}
+
 func NewCmdAliasAdd() *cobra.Command {
 
-	opt := &AliasAddOptions{}
+	opt := &AliasAddOptions{
+		scope: scopeBlock,
+	}
 
 	cmd := &cobra.Command{
 		Use:   "add [ip] [alias, ...]",
 		Short: fmt.Sprintf("Adds IP alias to %s file", hosts.EtcHosts.Path()),
-		Args:  cobra.ArbitraryArgs,
+		Long: fmt.Sprintf(`Adds IP alias to %s file.
+
+The aliases are read from arguments or, if no arguments specified, from stdin in hosts file format.
+With --upsert an alias already defined in the target block, or in any block with --scope=all,
+is pointed to the new IP instead of being added again, so repeated runs don't duplicate lines.
+With --prune the target block additionally loses all aliases not provided in the input.
+With --ttl or --expires the expiry time is recorded in the entry comment as "@expires: <time>",
+expired entries are removed by 'hostsctl gc'.`, hosts.EtcHosts.Path()),
+		Args: cobra.ArbitraryArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			cobra.CheckErr(opt.Complete(cmd, args))
-			cobra.CheckErr(opt.Validate())
-			cobra.CheckErr(opt.Execute())
+			common.RunCliCommand(opt, cmd, args)
 		},
 	}
 
 	cmd.Flags().StringVarP(&opt.blockIdOrName, "block", "b", opt.blockIdOrName, "Block id or name")
 	cmd.Flags().StringVarP(&opt.comment, "comment", "c", opt.comment, "Alias comment")
-	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Enforces creation of a named IP block if it is missing")
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Enforces creation of a named IP block if it is missing, allows adding to a locked block and changing system aliases with --upsert")
+	cmd.Flags().BoolVar(&opt.upsert, "upsert", opt.upsert, "Update existing aliases instead of adding duplicates, print a summary of changes")
+	cmd.Flags().StringVar(&opt.scope, "scope", opt.scope, fmt.Sprintf("Where to look for existing aliases with --upsert. One of %s,%s", scopeBlock, scopeAll))
+	cmd.Flags().BoolVar(&opt.checkConflict, "check-conflicts", opt.checkConflict, "Fail if the added aliases are already mapped to other IPs or duplicate existing ones, see 'alias conflicts'")
+	cmd.Flags().BoolVar(&opt.prune, "prune", opt.prune, "Used with --upsert, remove aliases of the target block which are not provided")
+	cmd.Flags().DurationVar(&opt.ttl, "ttl", opt.ttl, "Time the added aliases are valid for, e.g. 8h")
+	cmd.Flags().StringVar(&opt.expires, "expires", opt.expires, "Time the added aliases expire at, e.g. 2026-10-18T20:00:00Z or '2026-10-18 20:00' in local time")
+
+	common.AddDryRunFlags(cmd, &opt.dryRun)
 
 	return cmd
 }
 
+func (opt *AliasAddOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
 func (opt *AliasAddOptions) Complete(cmd *cobra.Command, args []string) error {
 
 	opt.command = cmd
@@ -50,6 +81,28 @@ func (opt *AliasAddOptions) Complete(cmd *cobra.Command, args []string) error {
 }
 
 func (opt *AliasAddOptions) Validate() error {
+	if opt.scope != scopeBlock && opt.scope != scopeAll {
+		return fmt.Errorf("scope %v is not supported; %w", opt.scope, common.ErrWrongArgumentValue)
+	}
+	if !opt.upsert && (opt.prune || opt.command.Flags().Changed("scope")) {
+		return fmt.Errorf("--prune and --scope can only be used with --upsert; %w", common.ErrWrongArgumentValue)
+	}
+	if opt.ttl != 0 && opt.expires != "" {
+		return fmt.Errorf("--ttl and --expires can't be used together; %w", common.ErrWrongArgumentValue)
+	}
+	if opt.ttl < 0 {
+		return fmt.Errorf("--ttl %v must be positive; %w", opt.ttl, common.ErrWrongArgumentValue)
+	}
+	if opt.expires != "" {
+		expiresAt, err := parseExpires(opt.expires)
+		if err != nil {
+			return err
+		}
+		if !expiresAt.After(common.Now(opt.command.Context())) {
+			return fmt.Errorf("--expires %s is in the past; %w", opt.expires, common.ErrWrongArgumentValue)
+		}
+		opt.expiresAt = expiresAt
+	}
 	return nil
 }
 
@@ -57,22 +110,61 @@ func (opt *AliasAddOptions) Execute() error {
 	aliases, err := readIpAliases(opt)
 	cobra.CheckErr(err)
 
-	src := hosts.NewSource(hosts.EtcHosts.Path(), common.FileSystem(opt.command.Context()))
+	if opt.ttl > 0 {
+		opt.expiresAt = common.Now(opt.command.Context()).Add(opt.ttl)
+	}
+	if !opt.expiresAt.IsZero() {
+		for _, a := range aliases {
+			a.SetExpires(opt.expiresAt)
+		}
+	}
+
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
 	doc, err := src.Load()
 	cobra.CheckErr(err)
+	locked := common.FindLockedBlocks(doc)
 
-	ipsBlock, err := findOrCreateTargetAliasesBlock(doc, opt.blockIdOrName, opt.force)
+	ipsBlock, err := common.FindOrCreateTargetAliasesBlock(doc, opt.blockIdOrName, opt.force)
 	cobra.CheckErr(err)
 
-	for _, a := range aliases {
-		ipsBlock.AddEntry(a)
+	existingConflicts := resolve.FindConflicts(doc)
+
+	var summary *upsertSummary
+	if opt.upsert {
+		scope := []*dom.IPAliasesBlock{ipsBlock}
+		if opt.scope == scopeAll {
+			scope = doc.IPBlocks()
+		}
+		summary, err = performUpsert(scope, ipsBlock, aliases, opt.prune, common.SystemAliases(opt.command.Context()), opt.force)
+		cobra.CheckErr(err)
+	} else {
+		for _, a := range aliases {
+			ipsBlock.AddEntry(a)
+		}
 	}
 
+	if opt.checkConflict {
+		added := newConflicts(existingConflicts, resolve.FindConflicts(doc))
+		if len(added) > 0 {
+			return fmt.Errorf("%d conflict(s) found, %s; %w", len(added), describeConflict(added[0]), common.ErrEntryAlreadyExists)
+		}
+	}
+
+	err = common.CheckLockedBlocks(doc, locked, opt.force)
+	cobra.CheckErr(err)
+
 	doc.Normalize()
 
 	err = src.Save(doc, dom.FmtKeep)
 	cobra.CheckErr(err)
 
+	if summary != nil {
+		fmt.Fprintln(opt.command.OutOrStdout(), summary)
+	}
+
 	return nil
 }
 
@@ -98,8 +190,12 @@ func readIpAliasFromArgs(opt *AliasAddOptions) (*dom.IPAliasesEntry, error) {
 	if !iptools.IsIP(args[0]) {
 		return nil, fmt.Errorf("%s is not an IP", args[0])
 	}
+	names, err := toASCIIAliases(args[1:])
+	if err != nil {
+		return nil, err
+	}
 	alias := dom.NewIPAliasesEntry(args[0])
-	for _, a := range args[1:] {
+	for _, a := range names {
 		alias.AddAlias(a)
 	}
 	if note := opt.comment; note != "" {
@@ -125,6 +221,11 @@ func readIpAliasesFromPassedInput(opt *AliasAddOptions) ([]*dom.IPAliasesEntry,
 			entries := ips.AliasEntries()
 			for _, a := range entries {
 				a.ClearFormatting()
+				names, err := toASCIIAliases(a.Aliases())
+				if err != nil {
+					return nil, err
+				}
+				a.SetAliases(names)
 			}
 			aliases = append(aliases, entries...)
 		case dom.Comments:
@@ -155,61 +256,25 @@ func readIpAliasesFromPassedInput(opt *AliasAddOptions) ([]*dom.IPAliasesEntry,
 	return aliases, nil
 }
 
-func findOrCreateTargetAliasesBlock(doc *dom.Document, ipBlockIdOrName string, createNamedIfMissing bool) (*dom.IPAliasesBlock, error) {
-
-	// #1 try to find ips block by id
-	var ipsBlock *dom.IPAliasesBlock
-	if ipBlockIdOrName != "" {
-		ipsBlock = doc.IPsBlockByIdOrName(ipBlockIdOrName)
-		if ipsBlock == nil && !createNamedIfMissing {
-			return nil, fmt.Errorf("aliases block '%s' was not found", ipBlockIdOrName)
+// Parses --expires value, see expiresLayouts
+func parseExpires(value string) (time.Time, error) {
+	for _, layout := range expiresLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
 		}
 	}
+	return time.Time{}, fmt.Errorf("--expires %s is not a time; %w", value, common.ErrWrongArgumentValue)
+}
 
-	// #2 try to find last ips block
-	if ipsBlock == nil && ipBlockIdOrName == "" {
-		blocks := doc.IPBlocks()
-
-		if len(blocks) > 0 {
-			lastIPsBlock := blocks[len(blocks)-1]
-			var lastIPBlockIfx dom.Block = lastIPsBlock
-
-			allBlocks := doc.Blocks()
-			lastIndex := slices.Index(allBlocks, lastIPBlockIfx)
-			foundBreakingBlock := false
-			for i := lastIndex + 1; i < len(allBlocks); i++ {
-				blockType := allBlocks[i].Type()
-				shouldBrak := false
-				switch blockType {
-				case dom.Blanks:
-					continue
-				default:
-					foundBreakingBlock = true
-					shouldBrak = true
-				}
-				if shouldBrak {
-					break
-				}
-			}
-			if !foundBreakingBlock {
-				ipsBlock = lastIPsBlock
-			}
-		}
-
-	}
-
-	// #3 try to create a new block
-	if ipsBlock == nil {
-		ipsBlock = dom.NewIPAliasesBlock()
-		if ipBlockIdOrName != "" {
-			v, err := strconv.Atoi(ipBlockIdOrName)
-			if err == nil {
-				ipsBlock.SetId(v)
-			} else {
-				ipsBlock.SetName(ipBlockIdOrName)
-			}
+// Validates aliases, internationalized aliases are converted to punycode
+func toASCIIAliases(aliases []string) ([]string, error) {
+	result := make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		ascii, err := iptools.ToASCIIHostname(a)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid alias; %w", a, common.ErrWrongArgumentValue)
 		}
-		doc.AddBlock(ipsBlock)
+		result = append(result, ascii)
 	}
-	return ipsBlock, nil
+	return result, nil
 }
@@ -2,12 +2,18 @@ package alias
 
 import (
 	"testing"
+	"time"
 
 	"github.com/0xcfff/hostsctl/commands/cmdtest"
 	"github.com/spf13/cobra"
 )
 
 func TestAliasAddCommand(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	upsertInput := "192.168.100.51 users.example.com\n" +
+		"192.168.100.60 orders.example.com\n" +
+		"192.168.100.54 statistics.example.com new.example.com\n"
+
 	tests := []cmdtest.ITTest{
 		{
 			Name: "add args - empty",
@@ -33,6 +39,27 @@ func TestAliasAddCommand(t *testing.T) {
 			},
 			Want: true,
 		},
+		{
+			Name: "add args - idn alias and ip with zone",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"fe80::1%eth0", "bücher.example", "router.local"},
+				InputFile:  "testdata/one-ip.txt",
+				OutputFile: "testdata/add/add_args_idn__one_ip__result.txt",
+				Stdout:     "",
+			},
+			Want: true,
+		},
+		{
+			Name: "add stdin - idn alias and ipv4-mapped ip",
+			Args: cmdtest.ITArgs{
+				Args:       []string{},
+				Stdin:      "::ffff:10.0.0.5 Пример.example",
+				InputFile:  "testdata/one-ip.txt",
+				OutputFile: "testdata/add/add_stdin_idn__one_ip__result.txt",
+				Stdout:     "",
+			},
+			Want: true,
+		},
 		{
 			Name: "add args - one line + comment",
 			Args: cmdtest.ITArgs{
@@ -69,6 +96,19 @@ func TestAliasAddCommand(t *testing.T) {
 			},
 			Want: true,
 		},
+		{
+			Name: "add args - custom hosts file",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"127.0.0.1", "my.domain.test"},
+				Stdin:      "",
+				HostsFile:  "/tmp/container/hosts",
+				InputFile:  "testdata/one-ip.txt",
+				OutputFile: "testdata/add/add_args__one_ip__result.txt",
+				Stdout:     "",
+				ErrorText:  "",
+			},
+			Want: true,
+		},
 		// add to specific block
 		{
 			Name: "add to block - no block specified",
@@ -142,8 +182,169 @@ func TestAliasAddCommand(t *testing.T) {
 			},
 			Want: true,
 		},
+		{
+			Name: "add args - dry run",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"127.0.0.1", "my.domain.test", "--dry-run"},
+				Stdin:      "",
+				InputFile:  "testdata/one-ip.txt",
+				OutputFile: "testdata/one-ip.txt",
+				StdoutFile: "testdata/add/add_dry_run__one_ip__output.txt",
+				ErrorText:  "",
+			},
+			Want: true,
+		},
+
+		{
+			Name: "add upsert - four blocks",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--block", "pet-prj2", "--upsert"},
+				Stdin:      upsertInput,
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/add/add_upsert__four_blocks__result.txt",
+				Stdout:     "1 added, 1 updated, 2 unchanged, 0 removed\n",
+				ErrorText:  "",
+			},
+			Want: true,
+		},
+		{
+			Name: "add upsert - repeated",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--block", "pet-prj2", "--upsert"},
+				Stdin:      upsertInput,
+				InputFile:  "testdata/add/add_upsert__four_blocks__result.txt",
+				OutputFile: "testdata/add/add_upsert__four_blocks__result.txt",
+				Stdout:     "0 added, 0 updated, 4 unchanged, 0 removed\n",
+				ErrorText:  "",
+			},
+			Want: true,
+		},
+		{
+			Name: "add upsert prune - four blocks",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--block", "pet-prj2", "--upsert", "--prune"},
+				Stdin:      upsertInput,
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/add/add_upsert_prune__four_blocks__result.txt",
+				Stdout:     "1 added, 1 updated, 2 unchanged, 5 removed\n",
+				ErrorText:  "",
+			},
+			Want: true,
+		},
+		{
+			Name: "add upsert - other IP notation",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"0:0:0:0:0:0:0:1", "ip6-localhost", "--block", "2", "--upsert"},
+				Stdin:      "",
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/four-blocks.txt",
+				Stdout:     "0 added, 0 updated, 1 unchanged, 0 removed\n",
+				ErrorText:  "",
+			},
+			Want: true,
+		},
+		{
+			Name: "add upsert prune - other IP notation",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--block", "2", "--upsert", "--prune"},
+				Stdin:      "0:0:0:0:0:0:0:1 ip6-localhost ip6-loopback\nfe00:0::0 ip6-localnet\nff00::0 ip6-mcastprefix\nff02::1 ip6-allnodes\nff02:0:0::2 ip6-allrouters\n",
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/four-blocks.txt",
+				Stdout:     "0 added, 0 updated, 6 unchanged, 0 removed\n",
+				ErrorText:  "",
+			},
+			Want: true,
+		},
+		{
+			Name: "add upsert - dual stack alias",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"10.0.0.2", "api.local", "-b", "app", "--upsert"},
+				InputFile:  "testdata/dual-stack.txt",
+				OutputFile: "testdata/add/add_upsert__dual_stack__result.txt",
+				Stdout:     "0 added, 1 updated, 0 unchanged, 0 removed\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "add upsert scope all - system alias, forced",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"10.0.0.9", "localhost", "-b", "app", "--upsert", "--scope", "all", "--force"},
+				InputFile:  "testdata/dual-stack.txt",
+				OutputFile: "testdata/add/add_upsert_system_forced__dual_stack__result.txt",
+				Stdout:     "0 added, 1 updated, 0 unchanged, 0 removed\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "error - upsert system alias",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"10.0.0.9", "localhost", "-b", "app", "--upsert", "--scope", "all"},
+				InputFile: "testdata/dual-stack.txt",
+				ErrorText: "1 of 1 entries is system",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - upsert prune system aliases",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"127.0.0.1", "localhost", "-b", "1", "--upsert", "--prune"},
+				InputFile: "testdata/dual-stack.txt",
+				ErrorText: "3 of 3 entries is system",
+			},
+			Want: false,
+		},
+		{
+			Name: "add upsert scope all - four blocks",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"10.0.0.1", "cats.example.org", "--block", "pet-prj2", "--upsert", "--scope", "all"},
+				Stdin:      "",
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/add/add_upsert_scope_all__four_blocks__result.txt",
+				Stdout:     "0 added, 1 updated, 0 unchanged, 0 removed\n",
+				ErrorText:  "",
+			},
+			Want: true,
+		},
+
+		{
+			Name: "add args - check conflicts, no conflicts",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"127.0.0.1", "my.domain.test", "--check-conflicts"},
+				Stdin:      "",
+				InputFile:  "testdata/one-ip.txt",
+				OutputFile: "testdata/add/add_args__one_ip__result.txt",
+				Stdout:     "",
+				ErrorText:  "",
+			},
+			Want: true,
+		},
 
 		// errors cases
+		{
+			Name: "error - dry run, exit code, changes",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"127.0.0.1", "my.domain.test", "--dry-run", "--exit-code"},
+				Stdin:      "",
+				InputFile:  "testdata/one-ip.txt",
+				OutputFile: "",
+				StdoutFile: "testdata/add/add_dry_run__one_ip__output.txt",
+				ErrorText:  "",
+				ExitCode:   2,
+			},
+			Want: false,
+		},
+		{
+			Name: "error - exit code without dry run",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"127.0.0.1", "my.domain.test", "--exit-code"},
+				Stdin:      "",
+				InputFile:  "testdata/one-ip.txt",
+				OutputFile: "",
+				Stdout:     "",
+				ErrorText:  "--exit-code can be used with --dry-run only",
+			},
+			Want: false,
+		},
 		{
 			Name: "error - missing block",
 			Args: cmdtest.ITArgs{
@@ -156,6 +357,167 @@ func TestAliasAddCommand(t *testing.T) {
 			},
 			Want: false,
 		},
+		{
+			Name: "error - prune without upsert",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"127.0.0.1", "my.domain.test", "--prune"},
+				Stdin:      "",
+				InputFile:  "testdata/one-ip.txt",
+				OutputFile: "",
+				Stdout:     "",
+				ErrorText:  "can only be used with --upsert",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - invalid alias",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"10.0.0.1", "my_host.local"},
+				InputFile: "testdata/one-ip.txt",
+				ErrorText: "my_host.local is not a valid alias; wrong argument value",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - numeric alias",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"10.0.0.1", "10.0.0.2"},
+				InputFile: "testdata/one-ip.txt",
+				ErrorText: "10.0.0.2 is not a valid alias; wrong argument value",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - invalid alias in stdin",
+			Args: cmdtest.ITArgs{
+				Args:      []string{},
+				Stdin:     "10.0.0.1 bad_name",
+				InputFile: "testdata/one-ip.txt",
+				ErrorText: "bad_name is not a valid alias; wrong argument value",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - ip out of range",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"999.0.0.1", "my.domain.test"},
+				InputFile: "testdata/one-ip.txt",
+				ErrorText: "999.0.0.1 is not an IP",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - unknown scope",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"127.0.0.1", "my.domain.test", "--upsert", "--scope", "doc"},
+				Stdin:      "",
+				InputFile:  "testdata/one-ip.txt",
+				OutputFile: "",
+				Stdout:     "",
+				ErrorText:  "scope doc is not supported",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - check conflicts, shadowed",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"10.9.9.9", "zipkin", "-b", "monitoring", "--check-conflicts"},
+				Stdin:      "",
+				InputFile:  "testdata/conflicts.txt",
+				OutputFile: "",
+				Stdout:     "",
+				ErrorText:  "shadowed alias zipkin 10.9.9.9 is already mapped to 192.168.100.64",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - check conflicts, duplicate",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"10.0.0.4", "kibana", "--check-conflicts"},
+				Stdin:      "",
+				InputFile:  "testdata/conflicts.txt",
+				OutputFile: "",
+				Stdout:     "",
+				ErrorText:  "duplicate alias kibana 10.0.0.4",
+			},
+			Want: false,
+		},
+		{
+			Name: "add to block - locked, forced",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"10.8.0.3", "wiki.corp.example.com", "-b", "corp-vpn", "--force"},
+				InputFile:  "testdata/locked-blocks.txt",
+				OutputFile: "testdata/add/add_locked_forced__locked_blocks__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "error - locked block",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"10.8.0.3", "wiki.corp.example.com", "-b", "corp-vpn"},
+				InputFile: "testdata/locked-blocks.txt",
+				ErrorText: "block [3] corp-vpn is locked, use --force to change it",
+			},
+			Want: false,
+		},
+		{
+			Name: "add args - ttl",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"10.0.0.9", "pr.demo.local", "-b", "demo", "-c", "review app", "--ttl", "8h"},
+				InputFile:  "testdata/expiring-aliases.txt",
+				OutputFile: "testdata/add/add_ttl__expiring_aliases__result.txt",
+				Now:        now,
+			},
+			Want: true,
+		},
+		{
+			Name: "add args - expires",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"10.0.0.9", "pr.demo.local", "-b", "demo", "--expires", "2026-10-20T18:00:00+02:00"},
+				InputFile:  "testdata/expiring-aliases.txt",
+				OutputFile: "testdata/add/add_expires__expiring_aliases__result.txt",
+				Now:        now,
+			},
+			Want: true,
+		},
+		{
+			Name: "add upsert ttl - alias shares line",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"192.168.100.54", "awards.example.com", "-b", "pet-prj2", "--upsert", "--ttl", "8h"},
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/add/add_upsert_ttl__four_blocks__result.txt",
+				Stdout:     "0 added, 1 updated, 0 unchanged, 0 removed\n",
+				Now:        now,
+			},
+			Want: true,
+		},
+		{
+			Name: "error - ttl and expires",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"10.0.0.9", "pr.demo.local", "--ttl", "8h", "--expires", "2026-10-20"},
+				InputFile: "testdata/expiring-aliases.txt",
+				ErrorText: "--ttl and --expires can't be used together",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - expires in the past",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"10.0.0.9", "pr.demo.local", "--expires", "2020-01-01"},
+				InputFile: "testdata/expiring-aliases.txt",
+				ErrorText: "is in the past",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - expires not a time",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"10.0.0.9", "pr.demo.local", "--expires", "tomorrow"},
+				InputFile: "testdata/expiring-aliases.txt",
+				ErrorText: "--expires tomorrow is not a time",
+			},
+			Want: false,
+		},
 	}
 
 	cmdtest.RunIntergationTests(t, tests, "TestAliasAddCommand", func() *cobra.Command { return NewCmdAliasAdd() })
@@ -14,6 +14,12 @@ func NewCmdAlias() *cobra.Command {
 	cmd.AddCommand(NewCmdAliasList())
 	cmd.AddCommand(NewCmdAliasAdd())
 	cmd.AddCommand(NewCmdAliasDelete())
+	cmd.AddCommand(NewCmdAliasDisable())
+	cmd.AddCommand(NewCmdAliasEnable())
+	cmd.AddCommand(NewCmdAliasEdit())
+	cmd.AddCommand(NewCmdAliasMove())
+	cmd.AddCommand(NewCmdAliasConflicts())
+	cmd.AddCommand(NewCmdAliasResolve())
 
 	return cmd
 }
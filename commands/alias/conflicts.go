@@ -0,0 +1,212 @@
+package alias
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/0xcfff/hostsctl/hosts/resolve"
+	"github.com/0xcfff/hostsctl/iotools"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	conflictsFormats = map[string]outFormat{
+		"":              fmtText,
+		common.TfmtText: fmtText,
+		common.TfmtJson: fmtJson,
+		common.TfmtYaml: fmtYaml,
+	}
+)
+
+type AliasConflictsOptions struct {
+	command      *cobra.Command
+	output       string
+	outputFormat outFormat
+	noHeaders    bool
+	fix          bool
+	force        bool
+	dryRun       common.DryRunOptions
+}
+
+func NewCmdAliasConflicts() *cobra.Command {
+
+	opt := &AliasConflictsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "conflicts [(-o|--output)=name]",
+		Short: fmt.Sprintf("Lists aliases defined more than once in %s", hosts.EtcHosts.Path()),
+		Long: fmt.Sprintf(`Lists aliases defined more than once in %s.
+
+Aliases are resolved using the first enabled line, one IP per address family,
+so mapping a name to both IPv4 and IPv6 addresses is not reported. Reported kinds:
+  duplicate   the alias is already mapped to the same IP
+  conflict    the alias is already mapped to a different IP in the same block
+  shadowed    the alias is already mapped to a different IP in an earlier block
+
+Duplicates are redundant and can be removed with --fix.`, hosts.EtcHosts.Path()),
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.RunCliCommand(opt, cmd, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opt.output, "output", "o", opt.output, fmt.Sprintf("Output format. One of %s", strings.Join(maps.Keys(conflictsFormats), ",")))
+	cmd.Flags().BoolVar(&opt.noHeaders, "no-headers", opt.noHeaders, "Don't print headers")
+	cmd.Flags().BoolVar(&opt.fix, "fix", opt.fix, "Remove duplicate aliases and list remaining conflicts")
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Used with --fix, remove duplicates from locked blocks too")
+
+	common.AddDryRunFlags(cmd, &opt.dryRun)
+
+	return cmd
+}
+
+func (opt *AliasConflictsOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
+func (opt *AliasConflictsOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	var ok bool
+	opt.outputFormat, ok = conflictsFormats[opt.output]
+	if !ok {
+		return fmt.Errorf("value %v is not support; %w", opt.output, common.ErrNotSupportedOutputFormat)
+	}
+
+	return nil
+}
+
+func (opt *AliasConflictsOptions) Validate() error {
+	args := opt.command.Flags().Args()
+	if len(args) > 0 {
+		return common.ErrTooManyArguments
+	}
+	if opt.dryRun.DryRun && !opt.fix {
+		return fmt.Errorf("--dry-run can be used with --fix only; %w", common.ErrWrongArgumentValue)
+	}
+	return nil
+}
+
+func (opt *AliasConflictsOptions) Execute() error {
+	var doc *dom.Document
+	var err error
+	removed := 0
+
+	if opt.fix {
+		src, err := common.LockedHostsSource(opt.command.Context())
+		cobra.CheckErr(err)
+		defer src.Unlock()
+
+		doc, err = src.Load()
+		cobra.CheckErr(err)
+		locked := common.FindLockedBlocks(doc)
+
+		removed = resolve.RemoveDuplicates(resolve.FindConflicts(doc))
+
+		err = common.CheckLockedBlocks(doc, locked, opt.force)
+		cobra.CheckErr(err)
+
+		doc.Normalize()
+
+		err = src.Save(doc, dom.FmtKeep)
+		cobra.CheckErr(err)
+	} else {
+		doc, err = common.HostsSource(opt.command.Context()).Load()
+		cobra.CheckErr(err)
+	}
+
+	m := NewConflictModels(resolve.FindConflicts(doc))
+
+	switch opt.outputFormat {
+	case fmtText:
+		err = writeConflictsAsText(opt, m)
+		if err == nil && opt.fix {
+			fmt.Fprintf(opt.command.OutOrStdout(), "%d duplicate alias(es) removed\n", removed)
+		}
+	case fmtJson:
+		err = writeConflictsAsJson(opt, m)
+	case fmtYaml:
+		err = writeConflictsAsYaml(opt, m)
+	default:
+		panic("unknown output format")
+	}
+	cobra.CheckErr(err)
+
+	return nil
+}
+
+func writeConflictsAsText(opt *AliasConflictsOptions, m []*ConflictModel) error {
+	if len(m) == 0 {
+		return nil
+	}
+	return iotools.PrintTabbed(opt.command.OutOrStdout(), nil, 2, func(w io.Writer) error {
+		if !opt.noHeaders {
+			fmt.Fprintln(w, strings.Join([]string{"KIND", "ALIAS", "IP", "LINE", "GROUP", "WINNER IP", "WINNER LINE", "WINNER GROUP"}, "\t"))
+		}
+		for _, c := range m {
+			values := []string{
+				c.Kind, c.Alias, c.IP, fmt.Sprint(c.Line), blockModelLabel(c.Block),
+				c.Winner.IP, fmt.Sprint(c.Winner.Line), blockModelLabel(c.Winner.Block),
+			}
+			fmt.Fprintln(w, strings.Join(values, "\t"))
+		}
+		return nil
+	})
+}
+
+func writeConflictsAsJson(opt *AliasConflictsOptions, m []*ConflictModel) error {
+	buff, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opt.command.OutOrStdout(), string(buff))
+	return nil
+}
+
+func writeConflictsAsYaml(opt *AliasConflictsOptions, m []*ConflictModel) error {
+	buff, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opt.command.OutOrStdout(), string(buff))
+	return nil
+}
+
+// Returns conflicts which are not in the list of earlier found ones
+func newConflicts(before []*resolve.AliasConflict, after []*resolve.AliasConflict) []*resolve.AliasConflict {
+	key := func(c *resolve.AliasConflict) string {
+		return fmt.Sprintf("%s %s %s", c.Kind, strings.ToLower(c.Occurrence.Alias), c.Occurrence.Addr)
+	}
+	known := make(map[string]int)
+	for _, c := range before {
+		known[key(c)]++
+	}
+	result := make([]*resolve.AliasConflict, 0)
+	for _, c := range after {
+		if known[key(c)] > 0 {
+			known[key(c)]--
+		} else {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func describeConflict(c *resolve.AliasConflict) string {
+	return fmt.Sprintf("%s alias %s %s is already mapped to %s", c.Kind, c.Occurrence.Alias, c.Occurrence.Entry.IP(), c.Winner.Entry.IP())
+}
+
+func blockModelLabel(b AliasBlockModel) string {
+	if b.Name != "" {
+		return b.Name
+	}
+	return fmt.Sprint(b.Id)
+}
@@ -0,0 +1,72 @@
+package alias
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestAliasConflictsCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "conflicts - no conflicts",
+			Args: cmdtest.ITArgs{
+				Args:       []string{},
+				InputFile:  "testdata/two-sys-blocks.txt",
+				OutputFile: "testdata/two-sys-blocks.txt",
+				Stdout:     "",
+			},
+			Want: true,
+		},
+		{
+			Name: "conflicts - conflicts",
+			Args: cmdtest.ITArgs{
+				Args:       []string{},
+				InputFile:  "testdata/conflicts.txt",
+				OutputFile: "testdata/conflicts.txt",
+				StdoutFile: "testdata/conflicts/conflicts__conflicts__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "conflicts json - conflicts",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-o", "json"},
+				InputFile:  "testdata/conflicts.txt",
+				StdoutFile: "testdata/conflicts/conflicts_json__conflicts__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "conflicts fix - conflicts",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--fix"},
+				InputFile:  "testdata/conflicts.txt",
+				OutputFile: "testdata/conflicts/conflicts_fix__conflicts__result.txt",
+				StdoutFile: "testdata/conflicts/conflicts_fix__conflicts__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "error - dry run without fix",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"--dry-run"},
+				InputFile: "testdata/conflicts.txt",
+				ErrorText: "--dry-run can be used with --fix only",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - wrong format",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"-o", "wide"},
+				InputFile: "testdata/conflicts.txt",
+				ErrorText: "value wide is not support",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestAliasConflictsCommand", func() *cobra.Command { return NewCmdAliasConflicts() })
+}
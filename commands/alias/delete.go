@@ -15,6 +15,7 @@ type AliasDeleteOptions struct {
 	blockIdOrName string
 	ipOrAlias     string
 	force         bool
+	dryRun        common.DryRunOptions
 }
 
 func NewCmdAliasDelete() *cobra.Command {
@@ -27,18 +28,22 @@ func NewCmdAliasDelete() *cobra.Command {
 		Aliases: []string{"remove", "rm"},
 		Args:    cobra.ArbitraryArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			cobra.CheckErr(opt.Complete(cmd, args))
-			cobra.CheckErr(opt.Validate())
-			cobra.CheckErr(opt.Execute())
+			common.RunCliCommand(opt, cmd, args)
 		},
 	}
 
 	cmd.Flags().StringVarP(&opt.blockIdOrName, "block", "b", opt.blockIdOrName, "Block id or name")
-	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Force command to succeed even if IP alias is not found")
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Force command to succeed even if IP alias is not found or is in a locked block")
+
+	common.AddDryRunFlags(cmd, &opt.dryRun)
 
 	return cmd
 }
 
+func (opt *AliasDeleteOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
 func (opt *AliasDeleteOptions) Complete(cmd *cobra.Command, args []string) error {
 
 	var err error
@@ -56,19 +61,26 @@ func (opt *AliasDeleteOptions) Validate() error {
 
 func (opt *AliasDeleteOptions) Execute() error {
 
-	src := hosts.NewSource(hosts.EtcHosts.Path(), common.FileSystem(opt.command.Context()))
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
 	doc, err := src.Load()
 	cobra.CheckErr(err)
+	locked := common.FindLockedBlocks(doc)
 
 	entriesMap, err := findEntriesToDelete(doc, opt)
 	cobra.CheckErr(err)
 
-	err = validateDelete(entriesMap, opt.ipOrAlias, opt.force)
+	err = validateDelete(entriesMap, opt.ipOrAlias, opt.force, common.SystemAliases(opt.command.Context()))
 	cobra.CheckErr(err)
 
 	err = performDelete(entriesMap, opt.ipOrAlias)
 	cobra.CheckErr(err)
 
+	err = common.CheckLockedBlocks(doc, locked, opt.force)
+	cobra.CheckErr(err)
+
 	doc.Normalize()
 
 	err = src.Save(doc, dom.FmtKeep)
@@ -120,7 +132,7 @@ func findEntriesToDelete(doc *dom.Document, opt *AliasDeleteOptions) (map[*dom.I
 	return entriesMap, nil
 }
 
-func validateDelete(foundEntries map[*dom.IPAliasesBlock][]*dom.IPAliasesEntry, ipOrAlias string, forceFlag bool) error {
+func validateDelete(foundEntries map[*dom.IPAliasesBlock][]*dom.IPAliasesEntry, ipOrAlias string, forceFlag bool, system *iptools.SystemAliasCatalog) error {
 
 	isAlias := !iptools.IsIP(ipOrAlias)
 
@@ -132,12 +144,12 @@ func validateDelete(foundEntries map[*dom.IPAliasesBlock][]*dom.IPAliasesEntry,
 
 		for _, ipe := range entries {
 			if isAlias {
-				if iptools.IsSystemAlias(ipe.IP(), ipOrAlias) {
+				if system.IsSystemAlias(ipe.IP(), ipOrAlias) {
 					systemCount += 1
 				}
 			} else {
 				for _, alias := range ipe.Aliases() {
-					if iptools.IsSystemAlias(ipOrAlias, alias) {
+					if system.IsSystemAlias(ipOrAlias, alias) {
 						systemCount += 1
 						break
 					}
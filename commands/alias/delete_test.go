@@ -69,6 +69,46 @@ func TestAliasDeleteCommand(t *testing.T) {
 			},
 			Want: true,
 		},
+		{
+			Name: "delete - dry run, exit code, no changes",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"missing.domain.test", "--force", "--dry-run", "--exit-code"},
+				Stdin:      "",
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/four-blocks.txt",
+				Stdout:     "",
+				ErrorText:  "",
+			},
+			Want: true,
+		},
+		{
+			Name: "delete error - distribution system alias",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"ip6-allnodes"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "1 of 1 entries is system",
+			},
+			Want: false,
+		},
+		{
+			Name: "delete error - hostname",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"laptop"},
+				InputFile: "testdata/four-blocks.txt",
+				Hostname:  "laptop.home",
+				ErrorText: "1 of 1 entries is system",
+			},
+			Want: false,
+		},
+		{
+			Name: "delete error - locked block",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"cats.example.org"},
+				InputFile: "testdata/locked-blocks.txt",
+				ErrorText: "block [15] pet-prj1 is locked by ansible, use --force to change it",
+			},
+			Want: false,
+		},
 	}
 
 	cmdtest.RunIntergationTests(t, tests, "TestAliasDeleteCommand", func() *cobra.Command { return NewCmdAliasDelete() })
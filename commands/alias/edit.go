@@ -0,0 +1,228 @@
+package alias
+
+import (
+	"fmt"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/0xcfff/hostsctl/iptools"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+)
+
+type AliasEditOptions struct {
+	command       *cobra.Command
+	blockIdOrName string
+	ipOrAlias     string
+	ip            string
+	addAliases    []string
+	removeAliases []string
+	comment       string
+	force         bool
+	dryRun        common.DryRunOptions
+}
+
+func NewCmdAliasEdit() *cobra.Command {
+
+	opt := &AliasEditOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "edit [ip or alias]",
+		Short: fmt.Sprintf("Changes IP aliases entry in %s file", hosts.EtcHosts.Path()),
+		Long: fmt.Sprintf(`Changes IP, aliases or comment of IP aliases entry in %s file.
+
+The whole line containing the IP or alias is changed, the line keeps its position
+in the block. Only one line is changed unless --force is specified.`, hosts.EtcHosts.Path()),
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.RunCliCommand(opt, cmd, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opt.blockIdOrName, "block", "b", opt.blockIdOrName, "Block id or name")
+	cmd.Flags().StringVar(&opt.ip, "ip", opt.ip, "New IP of the entry")
+	cmd.Flags().StringSliceVar(&opt.addAliases, "add-alias", opt.addAliases, "Aliases to add to the entry")
+	cmd.Flags().StringSliceVar(&opt.removeAliases, "remove-alias", opt.removeAliases, "Aliases to remove from the entry")
+	cmd.Flags().StringVarP(&opt.comment, "comment", "c", opt.comment, "New comment of the entry, empty value removes the comment")
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Force command to change system entries, entries of locked blocks and all entries found")
+
+	common.AddDryRunFlags(cmd, &opt.dryRun)
+
+	return cmd
+}
+
+func (opt *AliasEditOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
+func (opt *AliasEditOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	args = cmd.Flags().Args()
+	if len(args) > 1 {
+		return common.ErrTooManyArguments
+	}
+	if len(args) == 1 {
+		opt.ipOrAlias = args[0]
+	}
+
+	return nil
+}
+
+func (opt *AliasEditOptions) Validate() error {
+	if opt.ipOrAlias == "" {
+		return common.ErrIpOrAliasExpected
+	}
+	if opt.ip == "" && len(opt.addAliases) == 0 && len(opt.removeAliases) == 0 && !opt.command.Flags().Changed("comment") {
+		return fmt.Errorf("nothing to change, one of --ip, --add-alias, --remove-alias or --comment expected; %w", common.ErrNotEnoughArguments)
+	}
+	if opt.ip != "" && !iptools.IsIP(opt.ip) {
+		return fmt.Errorf("%s is not an IP; %w", opt.ip, common.ErrWrongArgumentValue)
+	}
+	var err error
+	opt.addAliases, err = toASCIIAliases(opt.addAliases)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (opt *AliasEditOptions) Execute() error {
+
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
+	doc, err := src.Load()
+	cobra.CheckErr(err)
+	locked := common.FindLockedBlocks(doc)
+
+	entriesMap, err := findEntries(doc, opt.blockIdOrName, opt.ipOrAlias, opt.force)
+	cobra.CheckErr(err)
+
+	err = validateEdit(entriesMap, opt)
+	cobra.CheckErr(err)
+
+	performEdit(entriesMap, opt)
+
+	err = common.CheckLockedBlocks(doc, locked, opt.force)
+	cobra.CheckErr(err)
+
+	doc.Normalize()
+
+	err = src.Save(doc, dom.FmtKeep)
+	cobra.CheckErr(err)
+
+	return nil
+}
+
+func validateEdit(foundEntries map[*dom.IPAliasesBlock][]*dom.IPAliasesEntry, opt *AliasEditOptions) error {
+	entriesCount, systemCount := countEntries(foundEntries, opt.ipOrAlias, common.SystemAliases(opt.command.Context()))
+
+	if systemCount > 0 && !opt.force {
+		return fmt.Errorf("%d of %d entries is system", systemCount, entriesCount)
+	}
+
+	if entriesCount == 0 && !opt.force {
+		return common.ErrAliasNotFound
+	}
+
+	if entriesCount > 1 && !opt.force {
+		return fmt.Errorf("%d entries found; %w", entriesCount, common.ErrTooManyEntries)
+	}
+
+	for _, entries := range foundEntries {
+		for _, ent := range entries {
+			remaining := len(ent.Aliases())
+			for _, a := range opt.removeAliases {
+				if slices.Contains(ent.Aliases(), a) && !slices.Contains(opt.addAliases, a) {
+					remaining--
+				}
+			}
+			for _, a := range opt.addAliases {
+				if !slices.Contains(ent.Aliases(), a) {
+					remaining++
+				}
+			}
+			if remaining <= 0 {
+				return fmt.Errorf("entry %s would have no aliases, use alias delete instead; %w", ent.IP(), common.ErrWrongArgumentValue)
+			}
+		}
+	}
+
+	return nil
+}
+
+func performEdit(foundEntries map[*dom.IPAliasesBlock][]*dom.IPAliasesEntry, opt *AliasEditOptions) {
+	for _, entries := range foundEntries {
+		for _, ent := range entries {
+			if opt.ip != "" {
+				ent.SetIP(opt.ip)
+			}
+			for _, a := range opt.removeAliases {
+				ent.RemoveAlias(a)
+			}
+			for _, a := range opt.addAliases {
+				ent.AddAlias(a)
+			}
+			if opt.command.Flags().Changed("comment") {
+				ent.SetNote(opt.comment)
+			}
+		}
+	}
+}
+
+// Finds entries by IP or alias in the specified block or in all blocks if the block is not specified
+func findEntries(doc *dom.Document, blockIdOrName string, ipOrAlias string, force bool) (map[*dom.IPAliasesBlock][]*dom.IPAliasesEntry, error) {
+
+	entriesMap := make(map[*dom.IPAliasesBlock][]*dom.IPAliasesEntry)
+
+	blocks := doc.IPBlocks()
+	if blockIdOrName != "" {
+		block := doc.IPsBlockByIdOrName(blockIdOrName)
+		if block == nil {
+			if !force {
+				return nil, fmt.Errorf("blockId: %s; %w", blockIdOrName, common.ErrBlockNotFound)
+			}
+			return entriesMap, nil
+		}
+		blocks = []*dom.IPAliasesBlock{block}
+	}
+
+	for _, block := range blocks {
+		entries := block.AliasEntriesByIPOrAlias(ipOrAlias)
+		if len(entries) > 0 {
+			entriesMap[block] = entries
+		}
+	}
+	return entriesMap, nil
+}
+
+// Returns number of found entries and number of them that are system
+func countEntries(foundEntries map[*dom.IPAliasesBlock][]*dom.IPAliasesEntry, ipOrAlias string, system *iptools.SystemAliasCatalog) (int, int) {
+	isAlias := !iptools.IsIP(ipOrAlias)
+
+	entriesCount := 0
+	systemCount := 0
+	for _, entries := range foundEntries {
+		entriesCount += len(entries)
+
+		for _, ipe := range entries {
+			if isAlias {
+				if system.IsSystemAlias(ipe.IP(), ipOrAlias) {
+					systemCount += 1
+				}
+			} else {
+				for _, alias := range ipe.Aliases() {
+					if system.IsSystemAlias(ipe.IP(), alias) {
+						systemCount += 1
+						break
+					}
+				}
+			}
+		}
+	}
+	return entriesCount, systemCount
+}
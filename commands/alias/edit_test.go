@@ -0,0 +1,114 @@
+package alias
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestAliasEditCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "edit - ip by alias",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"cats.example.org", "--ip", "192.168.100.201", "--add-alias", "kittens.example.org"},
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/edit/edit__ip_by_alias__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "edit - aliases by ip",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"192.168.100.53", "--remove-alias", "reports.example.com", "--add-alias", "reporting.example.com", "--comment", "moved"},
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/edit/edit__aliases_by_ip__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "edit - idn alias",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"cats.example.org", "--add-alias", "münchen.example"},
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/edit/edit_idn__four_blocks__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "edit force - many",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"reports.example.com", "--comment", "reports", "--force"},
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/edit/edit_force__many__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "edit - many",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"reports.example.com", "--comment", "reports"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "2 entries found",
+			},
+			Want: false,
+		},
+		{
+			Name: "edit - system",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"localhost", "--ip", "127.0.0.2"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "entries is system",
+			},
+			Want: false,
+		},
+		{
+			Name: "edit - no aliases left",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"cats.example.org", "--remove-alias", "cats.example.org"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "would have no aliases",
+			},
+			Want: false,
+		},
+		{
+			Name: "edit - invalid alias",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"cats.example.org", "--add-alias", "bad_name"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "is not a valid alias",
+			},
+			Want: false,
+		},
+		{
+			Name: "edit - invalid ip",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"cats.example.org", "--ip", "192.168.100"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "is not an IP",
+			},
+			Want: false,
+		},
+		{
+			Name: "edit - nothing to change",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"cats.example.org"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "nothing to change",
+			},
+			Want: false,
+		},
+		{
+			Name: "edit - not found",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"dogs.example.org", "--comment", "dogs"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "alias not found",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestAliasEditCommand", func() *cobra.Command { return NewCmdAliasEdit() })
+}
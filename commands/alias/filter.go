@@ -0,0 +1,86 @@
+package alias
+
+import (
+	"fmt"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/0xcfff/hostsctl/iptools"
+	"golang.org/x/exp/slices"
+)
+
+// Selects aliases to be listed
+type AliasFilter struct {
+	terms         []*common.FilterTerm
+	ips           []string
+	addrs         []iptools.AddrRange
+	blockIdOrName string
+	block         *dom.IPAliasesBlock
+	system        *iptools.SystemAliasCatalog
+	systemOnly    bool
+	noSystem      bool
+	disabledOnly  bool
+	comment       string
+}
+
+// Parses IP queries of the filter
+func (f *AliasFilter) parseIPs() error {
+	f.addrs = make([]iptools.AddrRange, 0, len(f.ips))
+	for _, ip := range f.ips {
+		r, err := iptools.ParseAddrRange(ip)
+		if err != nil {
+			return fmt.Errorf("%s is not an IP, network or range of IPs; %w", ip, common.ErrWrongArgumentValue)
+		}
+		f.addrs = append(f.addrs, r)
+	}
+	return nil
+}
+
+// Resolves block referenced by the filter, returns error if the block does not exist
+func (f *AliasFilter) bind(doc *dom.Document) error {
+	f.block = nil
+	if f.blockIdOrName != "" {
+		f.block = doc.IPsBlockByIdOrName(f.blockIdOrName)
+		if f.block == nil {
+			return fmt.Errorf("blockId: %s; %w", f.blockIdOrName, common.ErrBlockNotFound)
+		}
+	}
+	return nil
+}
+
+// Returns true if the alias of the entry satisfies all the filter conditions
+func (f *AliasFilter) Match(block *dom.IPAliasesBlock, entry *dom.IPAliasesEntry, alias string) bool {
+	if f == nil {
+		return true
+	}
+	if f.block != nil && f.block != block {
+		return false
+	}
+	if f.disabledOnly && !entry.Disabled() {
+		return false
+	}
+	if f.systemOnly || f.noSystem {
+		system := f.system.IsSystemAlias(entry.IP(), alias)
+		if (f.systemOnly && !system) || (f.noSystem && system) {
+			return false
+		}
+	}
+	if f.comment != "" && !common.ContainsFold(entry.Note(), f.comment) {
+		return false
+	}
+	if len(f.addrs) > 0 && slices.IndexFunc(f.addrs, func(r iptools.AddrRange) bool { return r.ContainsString(entry.IP()) }) < 0 {
+		return false
+	}
+	if len(f.terms) == 0 {
+		return true
+	}
+	for _, t := range f.terms {
+		if t.IsAddress() && t.MatchIP(entry.IP()) {
+			return true
+		}
+		if !t.IsAddress() && t.MatchName(alias) {
+			return true
+		}
+	}
+	return false
+}
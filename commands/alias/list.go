@@ -10,9 +10,9 @@ import (
 	"github.com/0xcfff/hostsctl/hosts"
 	"github.com/0xcfff/hostsctl/hosts/dom"
 	"github.com/0xcfff/hostsctl/iotools"
-	"github.com/0xcfff/hostsctl/iptools"
 	"github.com/spf13/cobra"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 	"gopkg.in/yaml.v3"
 )
 
@@ -46,6 +46,13 @@ var (
 	}
 )
 
+const listFilterHelp = `Filter values are matched against IP addresses and alias names, an alias is shown if it matches any of them:
+  192.168.1.10       IP address
+  192.168.0.0/16     IP addresses from the network
+  10.0.0.1-10.0.0.9  IP addresses from the range
+  *.example.com      alias names glob pattern
+  /^api[0-9]+\./     alias names regular expression`
+
 type AliasListOptions struct {
 	command        *cobra.Command
 	output         string
@@ -53,6 +60,7 @@ type AliasListOptions struct {
 	arrange        string
 	outputGrouping IPGrouping
 	noHeaders      bool
+	filter         AliasFilter
 }
 
 func NewCmdAliasList() *cobra.Command {
@@ -62,6 +70,7 @@ func NewCmdAliasList() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "list [(-o|--output)=name] [filter]",
 		Short:   fmt.Sprintf("Lists IP addresses and aliases defined in %s", hosts.EtcHosts.Path()),
+		Long:    fmt.Sprintf("Lists IP addresses and aliases defined in %s\n\n%s", hosts.EtcHosts.Path(), listFilterHelp),
 		Aliases: []string{"ls"},
 		Run: func(cmd *cobra.Command, args []string) {
 			cobra.CheckErr(opt.Complete(cmd, args))
@@ -73,6 +82,12 @@ func NewCmdAliasList() *cobra.Command {
 	cmd.Flags().BoolVar(&opt.noHeaders, "no-headers", opt.noHeaders, "Disable printing headers")
 	cmd.Flags().StringVarP(&opt.output, "output", "o", opt.output, fmt.Sprintf("Output format. One of %s", strings.Join(maps.Keys(formats), ",")))
 	cmd.Flags().StringVarP(&opt.arrange, "arrange", "a", opt.arrange, fmt.Sprintf("IPs output grouping. One of %s.", strings.Join(maps.Keys(groupings), ",")))
+	cmd.Flags().StringVarP(&opt.filter.blockIdOrName, "block", "b", opt.filter.blockIdOrName, "Show only aliases of the block with the specified id or name")
+	cmd.Flags().BoolVar(&opt.filter.systemOnly, "system", opt.filter.systemOnly, "Show only system aliases")
+	cmd.Flags().BoolVar(&opt.filter.noSystem, "no-system", opt.filter.noSystem, "Hide system aliases")
+	cmd.Flags().BoolVar(&opt.filter.disabledOnly, "disabled", opt.filter.disabledOnly, "Show only disabled aliases")
+	cmd.Flags().StringSliceVar(&opt.filter.ips, "ip", opt.filter.ips, "Show only aliases of the IPs, networks (192.168.100.0/24) or ranges (10.0.0.10-10.0.0.20)")
+	cmd.Flags().StringVar(&opt.filter.comment, "comment", opt.filter.comment, "Show only aliases which comment contains the specified text")
 
 	return cmd
 }
@@ -92,18 +107,34 @@ func (opt *AliasListOptions) Complete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("value %v is not support; %w", opt.arrange, common.ErrWrongArgumentValue)
 	}
 
+	var err error
+	opt.filter.terms, err = common.ParseFilterTerms(args)
+	if err != nil {
+		return err
+	}
+	err = opt.filter.parseIPs()
+	if err != nil {
+		return err
+	}
+	opt.filter.system = common.SystemAliases(cmd.Context())
+
 	return nil
 }
 
 func (opt *AliasListOptions) Validate() error {
+	if opt.filter.systemOnly && opt.filter.noSystem {
+		return fmt.Errorf("flags --system and --no-system can't be used together; %w", common.ErrWrongArgumentValue)
+	}
 	return nil
 }
 
 func (opt *AliasListOptions) Execute() error {
-	src := hosts.NewSource(hosts.EtcHosts.Path(), common.FileSystem(opt.command.Context()))
+	src := common.HostsSource(opt.command.Context())
 	c, err := src.Load()
 	cobra.CheckErr(err)
 
+	cobra.CheckErr(opt.filter.bind(c))
+
 	switch opt.outputFormat {
 	case fmtText, fmtShort, fmtWide:
 		err = writeDataAsText(opt, c)
@@ -122,12 +153,13 @@ func (opt *AliasListOptions) Execute() error {
 }
 
 func writeDataAsText(opt *AliasListOptions, data *dom.Document) error {
-	m := NewAliasesModels(data, opt.outputGrouping)
+	m := NewAliasesModels(data, opt.outputGrouping, &opt.filter, opt.filter.system)
+	now := common.Now(opt.command.Context())
 
 	err := iotools.PrintTabbed(opt.command.OutOrStdout(), nil, 2, func(w io.Writer) error {
 
 		if !opt.noHeaders {
-			columns := []string{"GRP", "SYS", "IP", "ALIAS", "COMMENT", "GROUP", "GROUP COMMENT"}
+			columns := []string{"GRP", "SYS", "IP", "ALIAS", "COMMENT", "GROUP", "GROUP COMMENT", "SYSTEM", "EXPIRES"}
 			visible := getVisibleValues(opt, columns)
 			fmt.Fprint(w, strings.Join(visible, "\t"))
 			fmt.Fprintln(w)
@@ -145,10 +177,14 @@ func writeDataAsText(opt *AliasListOptions, data *dom.Document) error {
 
 			sys := ""
 			cntSystem := 0
+			sources := make([]string, 0)
 
 			for _, alias := range ip.Aliases {
-				if iptools.IsSystemAlias(ip.IP, alias) {
+				if src, ok := ip.System[alias]; ok {
 					cntSystem += 1
+					if !slices.Contains(sources, string(src)) {
+						sources = append(sources, string(src))
+					}
 				}
 			}
 			if cntSystem == len(ip.Aliases) {
@@ -162,7 +198,12 @@ func writeDataAsText(opt *AliasListOptions, data *dom.Document) error {
 				gn = fmt.Sprint(ip.Block.Id)
 			}
 
-			values := []string{grp, sys, ip.IP, strings.Join(ip.Aliases, ", "), ip.Comment, gn, ip.Block.Comment}
+			expires := ""
+			if ip.Expires != nil {
+				expires = formatRemaining(ip.Expires.Sub(now))
+			}
+
+			values := []string{grp, sys, ip.hostsIP(), strings.Join(ip.Aliases, ", "), ip.Comment, gn, ip.Block.Comment, strings.Join(sources, ", "), expires}
 
 			visible := getVisibleValues(opt, values)
 			fmt.Fprint(w, strings.Join(visible, "\t"))
@@ -175,12 +216,12 @@ func writeDataAsText(opt *AliasListOptions, data *dom.Document) error {
 }
 
 func writeDataAsHosts(opt *AliasListOptions, data *dom.Document) error {
-	m := NewAliasesModels(data, opt.outputGrouping)
+	m := NewAliasesModels(data, opt.outputGrouping, &opt.filter, opt.filter.system)
 
 	err := iotools.PrintTabbed(opt.command.OutOrStdout(), nil, 2, func(w io.Writer) error {
 		for _, ip := range m {
 
-			values := []string{ip.IP, strings.Join(ip.Aliases, ", ")}
+			values := []string{ip.hostsIP(), strings.Join(ip.Aliases, ", ")}
 			fmt.Fprint(w, strings.Join(values, "\t"))
 			fmt.Fprintln(w)
 		}
@@ -190,7 +231,7 @@ func writeDataAsHosts(opt *AliasListOptions, data *dom.Document) error {
 }
 
 func writeDataAsJson(opt *AliasListOptions, data *dom.Document) error {
-	m := NewAliasesModels(data, opt.outputGrouping)
+	m := NewAliasesModels(data, opt.outputGrouping, &opt.filter, opt.filter.system)
 	buff, err := json.Marshal(m)
 	if err != nil {
 		return err
@@ -200,7 +241,7 @@ func writeDataAsJson(opt *AliasListOptions, data *dom.Document) error {
 }
 
 func writeDataAsYaml(opt *AliasListOptions, data *dom.Document) error {
-	m := NewAliasesModels(data, opt.outputGrouping)
+	m := NewAliasesModels(data, opt.outputGrouping, &opt.filter, opt.filter.system)
 	buff, err := yaml.Marshal(m)
 	if err != nil {
 		return err
@@ -210,7 +251,7 @@ func writeDataAsYaml(opt *AliasListOptions, data *dom.Document) error {
 }
 
 func getVisibleValues(opt *AliasListOptions, values []string) []string {
-	// "GRP", "SYS", "IP", "ALIAS", "COMMENT", "GROUP", "GROUP COMMENT"
+	// "GRP", "SYS", "IP", "ALIAS", "COMMENT", "GROUP", "GROUP COMMENT", "SYSTEM", "EXPIRES"
 	switch opt.outputFormat {
 	case fmtText:
 		return values[:4]
@@ -2,12 +2,14 @@ package alias
 
 import (
 	"testing"
+	"time"
 
 	"github.com/0xcfff/hostsctl/commands/cmdtest"
 	"github.com/spf13/cobra"
 )
 
 func TestAliasListCommand(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
 	tests := []cmdtest.ITTest{
 		// default
 		{
@@ -242,6 +244,179 @@ func TestAliasListCommand(t *testing.T) {
 			Want: true,
 		},
 
+		// filter
+		{
+			Name: "filter - glob in block",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"*.example.com", "-b", "pet-prj2"},
+				InputFile:  "testdata/four-blocks.txt",
+				StdoutFile: "testdata/list/filter_glob__four_blocks__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter - regex",
+			Args: cmdtest.ITArgs{
+				Args:       []string{`/^(orders|users)\./`},
+				InputFile:  "testdata/four-blocks.txt",
+				StdoutFile: "testdata/list/filter_regex__four_blocks__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter plain - ip and network",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"192.168.100.54", "127.0.0.0/8", "-o", "plain"},
+				InputFile:  "testdata/four-blocks.txt",
+				StdoutFile: "testdata/list/filter_ip_plain__four_blocks__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter json - system",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--system", "-o", "json"},
+				InputFile:  "testdata/four-blocks.txt",
+				StdoutFile: "testdata/list/filter_system_json__four_blocks__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter yaml - no system",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--no-system", "-b", "1", "-o", "yaml"},
+				InputFile:  "testdata/four-blocks.txt",
+				StdoutFile: "testdata/list/filter_no_system_yaml__four_blocks__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter wide - disabled",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--disabled", "-o", "wide"},
+				InputFile:  "testdata/disabled-entries.txt",
+				StdoutFile: "testdata/list/filter_disabled_wide__disabled_entries__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "list plain - disabled commented out",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"-b", "pet-prj2", "-o", "plain"},
+				InputFile: "testdata/disabled-entries.txt",
+				Stdout:    "192.168.100.51    users.example.com\n# 192.168.100.52  orders.example.com\n# 192.168.100.52  transactions.example.com\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "list json - disabled",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"-b", "pet-prj1", "-o", "json"},
+				InputFile: "testdata/disabled-entries.txt",
+				Stdout:    `[{"ip":"192.168.100.101","aliases":["cats.example.org"],"block":{"id":2,"name":"pet-prj1"},"disabled":true},{"ip":"192.168.100.102","aliases":["dogs.example.org"],"comment":"not ready yet","block":{"id":2,"name":"pet-prj1"},"disabled":true}]` + "\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter short - comment",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"--comment", "NOT READY", "-o", "short", "--no-headers"},
+				InputFile: "testdata/disabled-entries.txt",
+				Stdout:    "# 192.168.100.102  dogs.example.org\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter - nothing matches",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"10.0.0.0/8", "--no-headers"},
+				InputFile: "testdata/four-blocks.txt",
+				Stdout:    "",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter ip plain - network",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--ip", "192.168.100.0/24", "-o", "plain"},
+				InputFile:  "testdata/four-blocks.txt",
+				StdoutFile: "testdata/list/filter_ip_network_plain__four_blocks__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter ip - range and ipv6",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--ip", "192.168.100.50-192.168.100.60,::1"},
+				InputFile:  "testdata/four-blocks.txt",
+				StdoutFile: "testdata/list/filter_ip_range__four_blocks__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter ip short - different notation",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"--ip", "192.168.100.051", "--ip", "0:0:0:0:0:0:0:1", "-o", "short", "--no-headers"},
+				InputFile: "testdata/four-blocks.txt",
+				Stdout:    "::1             ip6-localhost\n::1             ip6-loopback\n192.168.100.51  users.example.com\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter ip - combined with glob",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"--ip", "192.168.100.52-192.168.100.54", "/^(orders|reports)\\./", "-o", "short", "--no-headers"},
+				InputFile: "testdata/four-blocks.txt",
+				Stdout:    "192.168.100.52  orders.example.com\n192.168.100.53  reports.example.com\n192.168.100.54  reports.example.com\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter error - wrong ip",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"--ip", "192.168.100.60-192.168.100.50"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "192.168.100.60-192.168.100.50 is not an IP, network or range of IPs; wrong argument value",
+			},
+			Want: false,
+		},
+		{
+			Name: "filter json - hostname",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"--system", "127.0.1.0/24", "-o", "json"},
+				InputFile: "testdata/four-blocks.txt",
+				Hostname:  "laptop.home",
+				Stdout:    `[{"ip":"127.0.1.1","aliases":["laptop"],"block":{"id":1},"system":{"laptop":"hostname"}}]` + "\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter error - block not found",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"-b", "not-existing"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "blockId: not-existing; block not found",
+			},
+			Want: false,
+		},
+		{
+			Name: "filter error - wrong regex",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"/(orders/"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "wrong argument value",
+			},
+			Want: false,
+		},
+		{
+			Name: "filter error - system and no-system",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"--system", "--no-system"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "flags --system and --no-system can't be used together; wrong argument value",
+			},
+			Want: false,
+		},
 		// wrong arguments check
 		{
 			Name: "error - wrong format",
@@ -267,6 +442,26 @@ func TestAliasListCommand(t *testing.T) {
 			},
 			Want: false,
 		},
+		{
+			Name: "list wide - expiring aliases",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-o", "wide"},
+				InputFile:  "testdata/expiring-aliases.txt",
+				StdoutFile: "testdata/list/list_wide__expiring_aliases__output.txt",
+				Now:        now,
+			},
+			Want: true,
+		},
+		{
+			Name: "list json - expiring aliases",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-o", "json", "demo.local"},
+				InputFile:  "testdata/expiring-aliases.txt",
+				StdoutFile: "testdata/list/list_json__expiring_aliases__output.txt",
+				Now:        now,
+			},
+			Want: true,
+		},
 	}
 
 	cmdtest.RunIntergationTests(t, tests, "TestAliasListCommand", func() *cobra.Command { return NewCmdAliasList() })
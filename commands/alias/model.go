@@ -1,17 +1,60 @@
 package alias
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/0xcfff/hostsctl/hosts/resolve"
+	"github.com/0xcfff/hostsctl/iptools"
 	"golang.org/x/exp/slices"
 )
 
 type AliasModel struct {
-	IP      string          `json:"ip"                yaml:"ip"`
-	Aliases []string        `json:"aliases"           yaml:"aliases"`
-	Comment string          `json:"comment,omitempty" yaml:"comment,omitempty"`
-	Block   AliasBlockModel `json:"block,omitempty"   yaml:"block,omitempty"`
+	IP       string                               `json:"ip"                yaml:"ip"`
+	Aliases  []string                             `json:"aliases"           yaml:"aliases"`
+	Comment  string                               `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Block    AliasBlockModel                      `json:"block,omitempty"   yaml:"block,omitempty"`
+	System   map[string]iptools.SystemAliasSource `json:"system,omitempty"  yaml:"system,omitempty"`
+	Expires  *time.Time                           `json:"expires,omitempty"  yaml:"expires,omitempty"`
+	Disabled bool                                 `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+}
+
+// Returns the IP as it is written in hosts file, disabled entries are commented out
+func (m *AliasModel) hostsIP() string {
+	if m.Disabled {
+		return "# " + m.IP
+	}
+	return m.IP
+}
+
+// Records expiry time of the entry if it expires earlier than the entries the model is already built from
+func (m *AliasModel) addExpires(entry *dom.IPAliasesEntry) {
+	if expires, ok := entry.Expires(); ok && (m.Expires == nil || expires.Before(*m.Expires)) {
+		m.Expires = &expires
+	}
+}
+
+// Returns time left till the expiry, e.g. 2d3h, 7h59m, 45m or expired
+func formatRemaining(remaining time.Duration) string {
+	if remaining <= 0 {
+		return "expired"
+	}
+	if remaining < time.Minute {
+		return "<1m"
+	}
+	days := int(remaining / (24 * time.Hour))
+	hours := int(remaining % (24 * time.Hour) / time.Hour)
+	minutes := int(remaining % time.Hour / time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
 }
 
 type AliasBlockModel struct {
@@ -28,32 +71,44 @@ const (
 	GrpGroup   IPGrouping = iota
 )
 
-func NewAliasesModels(doc *dom.Document, grouping IPGrouping) []*AliasModel {
+// Converts document IP blocks into aliases models, only aliases matching the filter are included.
+// System aliases are marked with the source they come from
+func NewAliasesModels(doc *dom.Document, grouping IPGrouping, filter *AliasFilter, system *iptools.SystemAliasCatalog) []*AliasModel {
 	var result []*AliasModel = make([]*AliasModel, 0)
 
 	for _, block := range doc.Blocks() {
 		if block.Type() == dom.IPList {
 			ipsBlock := block.(*dom.IPAliasesBlock)
-			result = append(result, convertIPs(ipsBlock, grouping)...)
+			result = append(result, convertIPs(ipsBlock, grouping, filter)...)
+		}
+	}
+	for _, m := range result {
+		for _, alias := range m.Aliases {
+			if sa, ok := system.Lookup(m.IP, alias); ok {
+				if m.System == nil {
+					m.System = make(map[string]iptools.SystemAliasSource)
+				}
+				m.System[alias] = sa.Source
+			}
 		}
 	}
 	return result
 }
 
-func convertIPs(ips *dom.IPAliasesBlock, grouping IPGrouping) []*AliasModel {
+func convertIPs(ips *dom.IPAliasesBlock, grouping IPGrouping, filter *AliasFilter) []*AliasModel {
 	switch grouping {
 	case GrpUngroup:
-		return ungroupAndConvert(ips)
+		return ungroupAndConvert(ips, filter)
 	case GrpGroup:
-		return groupAndConvert(ips)
+		return groupAndConvert(ips, filter)
 	case GrpRaw:
-		return convertOnly(ips)
+		return convertOnly(ips, filter)
 	default:
 		panic("unknown grouping specified")
 	}
 }
 
-func ungroupAndConvert(ips *dom.IPAliasesBlock) []*AliasModel {
+func ungroupAndConvert(ips *dom.IPAliasesBlock, filter *AliasFilter) []*AliasModel {
 	result := make([]*AliasModel, 0)
 
 	group := AliasBlockModel{
@@ -63,20 +118,22 @@ func ungroupAndConvert(ips *dom.IPAliasesBlock) []*AliasModel {
 	}
 
 	for _, r := range ips.AliasEntries() {
-		for _, al := range r.Aliases() {
+		for _, al := range filterAliases(ips, r, filter) {
 			ip := &AliasModel{
-				IP:      r.IP(),
-				Aliases: []string{al},
-				Comment: r.Note(),
-				Block:   group,
+				IP:       r.IP(),
+				Aliases:  []string{al},
+				Comment:  r.Note(),
+				Block:    group,
+				Disabled: r.Disabled(),
 			}
+			ip.addExpires(r)
 			result = append(result, ip)
 		}
 	}
 	return result
 }
 
-func groupAndConvert(ips *dom.IPAliasesBlock) []*AliasModel {
+func groupAndConvert(ips *dom.IPAliasesBlock, filter *AliasFilter) []*AliasModel {
 	result := make([]*AliasModel, 0)
 	ipsMap := make(map[string]*AliasModel)
 	ipsComments := make(map[string][]string)
@@ -88,35 +145,46 @@ func groupAndConvert(ips *dom.IPAliasesBlock) []*AliasModel {
 	}
 
 	for _, r := range ips.AliasEntries() {
-		ip, ok := ipsMap[r.IP()]
+		aliases := filterAliases(ips, r, filter)
+		if len(aliases) == 0 {
+			continue
+		}
+		// disabled entries are not merged with enabled entries of the same IP
+		key := r.IP()
+		if r.Disabled() {
+			key = "#" + key
+		}
+		ip, ok := ipsMap[key]
 		if !ok {
 			ip = &AliasModel{
-				IP:      r.IP(),
-				Block:   group,
-				Comment: r.Note(),
+				IP:       r.IP(),
+				Block:    group,
+				Comment:  r.Note(),
+				Disabled: r.Disabled(),
 			}
 			result = append(result, ip)
-			ipsMap[r.IP()] = ip
+			ipsMap[key] = ip
 
 			comments := make([]string, 0)
 			if r.Note() != "" {
 				comments = append(comments, r.Note())
 			}
-			ipsComments[r.IP()] = comments
+			ipsComments[key] = comments
 		}
-		ip.Aliases = append(ip.Aliases, r.Aliases()...)
+		ip.Aliases = append(ip.Aliases, aliases...)
+		ip.addExpires(r)
 
-		comments := ipsComments[ip.IP]
+		comments := ipsComments[key]
 		if ip.Comment != "" && !slices.Contains(comments, ip.Comment) {
 			comments = append(comments, r.Note())
-			ipsComments[ip.IP] = comments
+			ipsComments[key] = comments
 			ip.Comment = strings.Join(comments, ", ")
 		}
 	}
 	return result
 }
 
-func convertOnly(ips *dom.IPAliasesBlock) []*AliasModel {
+func convertOnly(ips *dom.IPAliasesBlock, filter *AliasFilter) []*AliasModel {
 	result := make([]*AliasModel, 0)
 
 	group := AliasBlockModel{
@@ -126,13 +194,120 @@ func convertOnly(ips *dom.IPAliasesBlock) []*AliasModel {
 	}
 
 	for _, r := range ips.AliasEntries() {
+		aliases := filterAliases(ips, r, filter)
+		if len(aliases) == 0 {
+			continue
+		}
 		ip := &AliasModel{
-			IP:      r.IP(),
-			Comment: r.Note(),
-			Aliases: r.Aliases(),
-			Block:   group,
+			IP:       r.IP(),
+			Comment:  r.Note(),
+			Aliases:  aliases,
+			Block:    group,
+			Disabled: r.Disabled(),
 		}
+		ip.addExpires(r)
 		result = append(result, ip)
 	}
 	return result
 }
+
+func filterAliases(ips *dom.IPAliasesBlock, entry *dom.IPAliasesEntry, filter *AliasFilter) []string {
+	result := make([]string, 0)
+	for _, al := range entry.Aliases() {
+		if filter.Match(ips, entry, al) {
+			result = append(result, al)
+		}
+	}
+	return result
+}
+
+type ConflictModel struct {
+	Kind   string              `json:"kind"   yaml:"kind"`
+	Alias  string              `json:"alias"  yaml:"alias"`
+	Family string              `json:"family" yaml:"family"`
+	IP     string              `json:"ip"     yaml:"ip"`
+	Line   int                 `json:"line"   yaml:"line"`
+	Block  AliasBlockModel     `json:"block"  yaml:"block"`
+	Winner ConflictWinnerModel `json:"winner" yaml:"winner"`
+}
+
+type ConflictWinnerModel struct {
+	IP    string          `json:"ip"    yaml:"ip"`
+	Line  int             `json:"line"  yaml:"line"`
+	Block AliasBlockModel `json:"block" yaml:"block"`
+}
+
+func NewConflictModels(conflicts []*resolve.AliasConflict) []*ConflictModel {
+	result := make([]*ConflictModel, 0, len(conflicts))
+	for _, c := range conflicts {
+		occ, winner := c.Occurrence, c.Winner
+		result = append(result, &ConflictModel{
+			Kind:   string(c.Kind),
+			Alias:  occ.Alias,
+			Family: occ.Family().String(),
+			IP:     occ.Entry.IP(),
+			Line:   occ.Entry.Line(),
+			Block:  newAliasBlockModel(occ.Block),
+			Winner: ConflictWinnerModel{
+				IP:    winner.Entry.IP(),
+				Line:  winner.Entry.Line(),
+				Block: newAliasBlockModel(winner.Block),
+			},
+		})
+	}
+	return result
+}
+
+func newAliasBlockModel(block *dom.IPAliasesBlock) AliasBlockModel {
+	return AliasBlockModel{
+		Id:      block.Id(),
+		Name:    block.Name(),
+		Comment: block.Note(),
+	}
+}
+
+type ResolveModel struct {
+	Name      string                 `json:"name"      yaml:"name"`
+	Addresses []*ResolveAddressModel `json:"addresses" yaml:"addresses"`
+}
+
+type ResolveAddressModel struct {
+	Family            string `json:"family"   yaml:"family"`
+	ResolveEntryModel `yaml:",inline"`
+	Shadowed          []*ResolveEntryModel `json:"shadowed" yaml:"shadowed"`
+}
+
+type ResolveEntryModel struct {
+	IP    string          `json:"ip"    yaml:"ip"`
+	Alias string          `json:"alias" yaml:"alias"`
+	Line  int             `json:"line"  yaml:"line"`
+	Block AliasBlockModel `json:"block" yaml:"block"`
+}
+
+func NewResolveModel(name string, answers []*resolve.Answer) *ResolveModel {
+	m := &ResolveModel{
+		Name:      name,
+		Addresses: make([]*ResolveAddressModel, 0, len(answers)),
+	}
+	for _, a := range answers {
+		am := &ResolveAddressModel{
+			Family:            a.Family.String(),
+			ResolveEntryModel: *newResolveEntryModel(a.Winner),
+			Shadowed:          make([]*ResolveEntryModel, 0, len(a.Shadowed)),
+		}
+		for _, s := range a.Shadowed {
+			am.Shadowed = append(am.Shadowed, newResolveEntryModel(s))
+		}
+		m.Addresses = append(m.Addresses, am)
+	}
+	return m
+}
+
+func newResolveEntryModel(occ *resolve.Occurrence) *ResolveEntryModel {
+	return &ResolveEntryModel{
+		IP:    occ.Entry.IP(),
+		Alias: occ.Alias,
+		Line:  occ.Entry.Line(),
+		Block: newAliasBlockModel(occ.Block),
+	}
+}
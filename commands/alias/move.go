@@ -0,0 +1,159 @@
+package alias
+
+import (
+	"fmt"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/0xcfff/hostsctl/iptools"
+	"github.com/spf13/cobra"
+)
+
+type AliasMoveOptions struct {
+	command       *cobra.Command
+	blockIdOrName string
+	toBlock       string
+	ipOrAlias     string
+	force         bool
+	dryRun        common.DryRunOptions
+}
+
+func NewCmdAliasMove() *cobra.Command {
+
+	opt := &AliasMoveOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "move [ip or alias] --to-block=block",
+		Short: fmt.Sprintf("Moves IP aliases entries to another block in %s file", hosts.EtcHosts.Path()),
+		Long: fmt.Sprintf(`Moves IP aliases entries to another block in %s file.
+
+Lines with the IP, or the alias, are moved to the end of the target block keeping
+their formatting. If the alias shares a line with other aliases, it is split
+into a separate line. Only one line is moved unless --force is specified.`, hosts.EtcHosts.Path()),
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.RunCliCommand(opt, cmd, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opt.blockIdOrName, "block", "b", opt.blockIdOrName, "Id or name of the block to move entries from")
+	cmd.Flags().StringVar(&opt.toBlock, "to-block", opt.toBlock, "Id or name of the block to move entries to")
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Force command to move system entries, entries of locked blocks and all entries found, create the target block if it is missing")
+
+	common.AddDryRunFlags(cmd, &opt.dryRun)
+
+	return cmd
+}
+
+func (opt *AliasMoveOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
+func (opt *AliasMoveOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	args = cmd.Flags().Args()
+	if len(args) > 1 {
+		return common.ErrTooManyArguments
+	}
+	if len(args) == 1 {
+		opt.ipOrAlias = args[0]
+		// aliases are stored in punycode, see alias add
+		if ascii, err := iptools.ToASCIIHostname(opt.ipOrAlias); err == nil && !iptools.IsIP(opt.ipOrAlias) {
+			opt.ipOrAlias = ascii
+		}
+	}
+
+	return nil
+}
+
+func (opt *AliasMoveOptions) Validate() error {
+	if opt.ipOrAlias == "" {
+		return common.ErrIpOrAliasExpected
+	}
+	if opt.toBlock == "" {
+		return fmt.Errorf("target block expected; %w", common.ErrNotEnoughArguments)
+	}
+	return nil
+}
+
+func (opt *AliasMoveOptions) Execute() error {
+
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
+	doc, err := src.Load()
+	cobra.CheckErr(err)
+	locked := common.FindLockedBlocks(doc)
+
+	entriesMap, err := findEntries(doc, opt.blockIdOrName, opt.ipOrAlias, opt.force)
+	cobra.CheckErr(err)
+	if existing := doc.IPsBlockByIdOrName(opt.toBlock); existing != nil {
+		delete(entriesMap, existing)
+	}
+
+	err = validateMove(entriesMap, opt)
+	cobra.CheckErr(err)
+	if entriesCount, _ := countEntries(entriesMap, opt.ipOrAlias, common.SystemAliases(opt.command.Context())); entriesCount == 0 {
+		// nothing to move with --force, the target block is not created for nothing
+		return nil
+	}
+
+	// the target is created only after the sources are validated
+	target, err := common.FindOrCreateTargetAliasesBlock(doc, opt.toBlock, opt.force)
+	cobra.CheckErr(err)
+
+	performMove(doc, entriesMap, target, opt.ipOrAlias)
+
+	err = common.CheckLockedBlocks(doc, locked, opt.force)
+	cobra.CheckErr(err)
+
+	doc.Normalize()
+
+	err = src.Save(doc, dom.FmtKeep)
+	cobra.CheckErr(err)
+
+	return nil
+}
+
+func validateMove(foundEntries map[*dom.IPAliasesBlock][]*dom.IPAliasesEntry, opt *AliasMoveOptions) error {
+	entriesCount, systemCount := countEntries(foundEntries, opt.ipOrAlias, common.SystemAliases(opt.command.Context()))
+
+	if systemCount > 0 && !opt.force {
+		return fmt.Errorf("%d of %d entries is system", systemCount, entriesCount)
+	}
+
+	if entriesCount == 0 && !opt.force {
+		return common.ErrAliasNotFound
+	}
+
+	if entriesCount > 1 && !opt.force {
+		return fmt.Errorf("%d entries found; %w", entriesCount, common.ErrTooManyEntries)
+	}
+
+	return nil
+}
+
+func performMove(doc *dom.Document, foundEntries map[*dom.IPAliasesBlock][]*dom.IPAliasesEntry, target *dom.IPAliasesBlock, ipOrAlias string) {
+	isAlias := !iptools.IsIP(ipOrAlias)
+	// blocks are visited in the document order, so the moved entries keep their relative order
+	for _, block := range doc.IPBlocks() {
+		for _, entry := range foundEntries[block] {
+			if isAlias && len(entry.Aliases()) > 1 {
+				// leave other aliases of the line in place
+				entry.RemoveAlias(ipOrAlias)
+				split := dom.NewIPAliasesEntry(entry.IP())
+				split.AddAlias(ipOrAlias)
+				split.SetNote(entry.Note())
+				split.SetDisabled(entry.Disabled())
+				target.AddEntry(split)
+			} else {
+				block.RemoveEntry(entry)
+				target.AddEntry(entry)
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package alias
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestAliasMoveCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "move - by alias",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"statistics.example.com", "--to-block", "pet-prj1"},
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/move/move__by_alias__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "move force - by ip",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"192.168.100.52", "--to-block", "pet-prj1", "--force"},
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/move/move_force__by_ip__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "move force - many blocks",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"grafana", "--to-block", "monitoring", "--force"},
+				InputFile:  "testdata/conflicts.txt",
+				OutputFile: "testdata/move/move_force__many_blocks__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "move force - alias not found, no target block",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"missing.example.org", "--to-block", "new-block", "--force"},
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/four-blocks.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "move force - idn alias",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"bücher.example", "--to-block", "new-block", "--force"},
+				InputFile:  "testdata/idn.txt",
+				OutputFile: "testdata/move/move_force__idn_alias__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "move - to empty block",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"laptop", "--to-block", "pet-prj1"},
+				InputFile:  "testdata/two-blocks-one-empty.txt",
+				OutputFile: "testdata/move/move__to_empty_block__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "move - disabled",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"192.168.100.52", "--to-block", "pet-prj1"},
+				InputFile:  "testdata/disabled-entries.txt",
+				OutputFile: "testdata/move/move__disabled__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "move - many",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"192.168.100.52", "--to-block", "pet-prj1"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "2 entries found",
+			},
+			Want: false,
+		},
+		{
+			Name: "move - system",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"localhost", "--to-block", "pet-prj1"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "entries is system",
+			},
+			Want: false,
+		},
+		{
+			Name: "move - block not found",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"cats.example.org", "--to-block", "pet-prj9"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "was not found",
+			},
+			Want: false,
+		},
+		{
+			Name: "move - no target block",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"cats.example.org"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "target block expected",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestAliasMoveCommand", func() *cobra.Command { return NewCmdAliasMove() })
+}
@@ -0,0 +1,157 @@
+package alias
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/resolve"
+	"github.com/0xcfff/hostsctl/iotools"
+	"github.com/0xcfff/hostsctl/iptools"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	resolveFormats = map[string]outFormat{
+		"":              fmtText,
+		common.TfmtText: fmtText,
+		common.TfmtJson: fmtJson,
+		common.TfmtYaml: fmtYaml,
+	}
+)
+
+type AliasResolveOptions struct {
+	command      *cobra.Command
+	name         string
+	output       string
+	outputFormat outFormat
+	noHeaders    bool
+}
+
+func NewCmdAliasResolve() *cobra.Command {
+
+	opt := &AliasResolveOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "resolve <alias> [(-o|--output)=name]",
+		Short: fmt.Sprintf("Shows IPs the alias is resolved to using %s", hosts.EtcHosts.Path()),
+		Long: fmt.Sprintf(`Shows IPs the alias is resolved to using %s.
+
+The lookup is emulated the way system resolvers read the file: the first enabled line
+with the alias wins for each address family, names are matched case insensitively.
+Later lines with the alias are shown as shadowed, they are never used.`, hosts.EtcHosts.Path()),
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(opt.Complete(cmd, args))
+			cobra.CheckErr(opt.Validate())
+			cobra.CheckErr(opt.Execute())
+		},
+	}
+
+	cmd.Flags().StringVarP(&opt.output, "output", "o", opt.output, fmt.Sprintf("Output format. One of %s", strings.Join(maps.Keys(resolveFormats), ",")))
+	cmd.Flags().BoolVar(&opt.noHeaders, "no-headers", opt.noHeaders, "Don't print headers")
+
+	return cmd
+}
+
+func (opt *AliasResolveOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	var ok bool
+	opt.outputFormat, ok = resolveFormats[opt.output]
+	if !ok {
+		return fmt.Errorf("value %v is not support; %w", opt.output, common.ErrNotSupportedOutputFormat)
+	}
+
+	args = cmd.Flags().Args()
+	if len(args) == 1 {
+		opt.name = args[0]
+	}
+
+	return nil
+}
+
+func (opt *AliasResolveOptions) Validate() error {
+	args := opt.command.Flags().Args()
+	if len(args) > 1 {
+		return common.ErrTooManyArguments
+	}
+	if opt.name == "" {
+		return fmt.Errorf("alias expected; %w", common.ErrNotEnoughArguments)
+	}
+	return nil
+}
+
+func (opt *AliasResolveOptions) Execute() error {
+	doc, err := common.HostsSource(opt.command.Context()).Load()
+	cobra.CheckErr(err)
+
+	name := opt.name
+	if ascii, err := iptools.ToASCIIHostname(name); err == nil {
+		name = ascii
+	}
+	answers := resolve.Resolve(doc, name)
+	if len(answers) == 0 {
+		return fmt.Errorf("%s; %w", opt.name, common.ErrAliasNotFound)
+	}
+
+	m := NewResolveModel(opt.name, answers)
+
+	switch opt.outputFormat {
+	case fmtText:
+		err = writeResolveAsText(opt, m)
+	case fmtJson:
+		err = writeResolveAsJson(opt, m)
+	case fmtYaml:
+		err = writeResolveAsYaml(opt, m)
+	default:
+		panic("unknown output format")
+	}
+	cobra.CheckErr(err)
+
+	return nil
+}
+
+func writeResolveAsText(opt *AliasResolveOptions, m *ResolveModel) error {
+	return iotools.PrintTabbed(opt.command.OutOrStdout(), nil, 2, func(w io.Writer) error {
+		if !opt.noHeaders {
+			fmt.Fprintln(w, strings.Join([]string{"FAMILY", "IP", "ALIAS", "LINE", "GROUP", "STATUS"}, "\t"))
+		}
+		for _, a := range m.Addresses {
+			writeResolveEntry(w, a.Family, &a.ResolveEntryModel, "resolved")
+			for _, s := range a.Shadowed {
+				writeResolveEntry(w, a.Family, s, "shadowed")
+			}
+		}
+		return nil
+	})
+}
+
+func writeResolveEntry(w io.Writer, family string, e *ResolveEntryModel, status string) {
+	values := []string{family, e.IP, e.Alias, fmt.Sprint(e.Line), blockModelLabel(e.Block), status}
+	fmt.Fprintln(w, strings.Join(values, "\t"))
+}
+
+func writeResolveAsJson(opt *AliasResolveOptions, m *ResolveModel) error {
+	buff, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opt.command.OutOrStdout(), string(buff))
+	return nil
+}
+
+func writeResolveAsYaml(opt *AliasResolveOptions, m *ResolveModel) error {
+	buff, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opt.command.OutOrStdout(), string(buff))
+	return nil
+}
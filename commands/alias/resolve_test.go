@@ -0,0 +1,69 @@
+package alias
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestAliasResolveCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "resolve - shadowed",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"ZIPKIN"},
+				InputFile:  "testdata/conflicts.txt",
+				StdoutFile: "testdata/resolve/resolve__shadowed__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "resolve json - both families",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"grafana", "-o", "json"},
+				InputFile:  "testdata/conflicts.txt",
+				StdoutFile: "testdata/resolve/resolve_json__both_families__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "resolve - no headers",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"laptop", "--no-headers"},
+				InputFile: "testdata/conflicts.txt",
+				Stdout:    "ipv4  127.0.1.1  laptop  2  1  resolved\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "resolve - idn alias",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"bücher.example", "--no-headers"},
+				InputFile: "testdata/idn.txt",
+				Stdout:    "ipv6  fe80::1%eth0  xn--bcher-kva.example  4  lan  resolved\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "error - not found",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"birds"},
+				InputFile: "testdata/conflicts.txt",
+				ErrorText: "alias not found",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - no alias",
+			Args: cmdtest.ITArgs{
+				Args:      []string{},
+				InputFile: "testdata/conflicts.txt",
+				ErrorText: "alias expected",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestAliasResolveCommand", func() *cobra.Command { return NewCmdAliasResolve() })
+}
@@ -0,0 +1,206 @@
+package alias
+
+import (
+	"fmt"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/0xcfff/hostsctl/iptools"
+	"github.com/spf13/cobra"
+)
+
+type AliasToggleOptions struct {
+	command       *cobra.Command
+	blockIdOrName string
+	ipOrAlias     string
+	disable       bool
+	force         bool
+	dryRun        common.DryRunOptions
+}
+
+func NewCmdAliasDisable() *cobra.Command {
+	return newCmdAliasToggle(true)
+}
+
+func NewCmdAliasEnable() *cobra.Command {
+	return newCmdAliasToggle(false)
+}
+
+func newCmdAliasToggle(disable bool) *cobra.Command {
+
+	opt := &AliasToggleOptions{
+		disable: disable,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "enable [ip or alias]",
+		Short: fmt.Sprintf("Enables commented out IP aliases in %s file", hosts.EtcHosts.Path()),
+		Args:  cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.RunCliCommand(opt, cmd, args)
+		},
+	}
+	if disable {
+		cmd.Use = "disable [ip or alias]"
+		cmd.Short = fmt.Sprintf("Disables IP aliases in %s file by commenting them out", hosts.EtcHosts.Path())
+	}
+
+	cmd.Flags().StringVarP(&opt.blockIdOrName, "block", "b", opt.blockIdOrName, "Block id or name, all aliases of the block are affected if no IP or alias is specified")
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Force command to succeed even if IP alias is not found, is system or is in a locked block")
+
+	common.AddDryRunFlags(cmd, &opt.dryRun)
+
+	return cmd
+}
+
+func (opt *AliasToggleOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
+func (opt *AliasToggleOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	args = cmd.Flags().Args()
+	if len(args) > 1 {
+		return common.ErrTooManyArguments
+	}
+	if len(args) == 1 {
+		opt.ipOrAlias = args[0]
+	}
+
+	return nil
+}
+
+func (opt *AliasToggleOptions) Validate() error {
+	if opt.ipOrAlias == "" && opt.blockIdOrName == "" {
+		return common.ErrIpOrAliasExpected
+	}
+	return nil
+}
+
+func (opt *AliasToggleOptions) Execute() error {
+
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
+	doc, err := src.Load()
+	cobra.CheckErr(err)
+	locked := common.FindLockedBlocks(doc)
+
+	entriesMap, err := findEntriesToToggle(doc, opt)
+	cobra.CheckErr(err)
+
+	err = validateToggle(entriesMap, opt)
+	cobra.CheckErr(err)
+
+	err = performToggle(entriesMap, opt)
+	cobra.CheckErr(err)
+
+	err = common.CheckLockedBlocks(doc, locked, opt.force)
+	cobra.CheckErr(err)
+
+	doc.Normalize()
+
+	err = src.Save(doc, dom.FmtKeep)
+	cobra.CheckErr(err)
+
+	return nil
+}
+
+func findEntriesToToggle(doc *dom.Document, opt *AliasToggleOptions) (map[*dom.IPAliasesBlock][]*dom.IPAliasesEntry, error) {
+
+	entriesMap := make(map[*dom.IPAliasesBlock][]*dom.IPAliasesEntry)
+
+	blocks := doc.IPBlocks()
+	if opt.blockIdOrName != "" {
+		block := doc.IPsBlockByIdOrName(opt.blockIdOrName)
+		if block == nil {
+			if !opt.force {
+				return nil, fmt.Errorf("blockId: %s; %w", opt.blockIdOrName, common.ErrBlockNotFound)
+			}
+			return entriesMap, nil
+		}
+		blocks = []*dom.IPAliasesBlock{block}
+	}
+
+	for _, block := range blocks {
+		var candidates []*dom.IPAliasesEntry
+		if opt.ipOrAlias == "" {
+			candidates = block.AliasEntries()
+		} else {
+			candidates = block.AliasEntriesByIPOrAlias(opt.ipOrAlias)
+		}
+
+		entries := make([]*dom.IPAliasesEntry, 0)
+		for _, ent := range candidates {
+			if ent.Disabled() != opt.disable {
+				entries = append(entries, ent)
+			}
+		}
+		if len(entries) > 0 {
+			entriesMap[block] = entries
+		}
+	}
+	return entriesMap, nil
+}
+
+func validateToggle(foundEntries map[*dom.IPAliasesBlock][]*dom.IPAliasesEntry, opt *AliasToggleOptions) error {
+
+	isAlias := opt.ipOrAlias != "" && !iptools.IsIP(opt.ipOrAlias)
+	system := common.SystemAliases(opt.command.Context())
+
+	entriesCount := 0
+	systemCount := 0
+
+	for _, entries := range foundEntries {
+		entriesCount += len(entries)
+
+		for _, ipe := range entries {
+			if isAlias {
+				if system.IsSystemAlias(ipe.IP(), opt.ipOrAlias) {
+					systemCount += 1
+				}
+			} else {
+				for _, alias := range ipe.Aliases() {
+					if system.IsSystemAlias(ipe.IP(), alias) {
+						systemCount += 1
+						break
+					}
+				}
+			}
+		}
+	}
+
+	if opt.disable && systemCount > 0 && !opt.force {
+		return fmt.Errorf("%d of %d entries is system", systemCount, entriesCount)
+	}
+
+	if entriesCount == 0 && !opt.force {
+		return common.ErrAliasNotFound
+	}
+
+	return nil
+}
+
+func performToggle(foundEntries map[*dom.IPAliasesBlock][]*dom.IPAliasesEntry, opt *AliasToggleOptions) error {
+	isAlias := opt.ipOrAlias != "" && !iptools.IsIP(opt.ipOrAlias)
+	for block, entries := range foundEntries {
+		for _, entry := range entries {
+			if isAlias && len(entry.Aliases()) > 1 {
+				// split the alias into a separate line next to the original one
+				entry.RemoveAlias(opt.ipOrAlias)
+				split := dom.NewIPAliasesEntry(entry.IP())
+				split.AddAlias(opt.ipOrAlias)
+				split.SetNote(entry.Note())
+				split.SetDisabled(opt.disable)
+				block.InsertEntry(block.IndexOfEntry(entry)+1, split)
+			} else {
+				entry.SetDisabled(opt.disable)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package alias
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestAliasDisableCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "disable - by ip",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"192.168.100.52"},
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/toggle/disable__by_ip__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "disable - by alias",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"awards.example.com"},
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/toggle/disable__by_alias__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "disable - block",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-b", "pet-prj1"},
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/toggle/disable__block__result.txt",
+			},
+			Want: true,
+		},
+
+		// errors cases
+		{
+			Name: "disable error - system",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"localhost"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "1 of 1 entries is system",
+			},
+			Want: false,
+		},
+		{
+			Name: "disable error - not found",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"cats.example.org"},
+				InputFile: "testdata/disabled-entries.txt",
+				ErrorText: "alias not found",
+			},
+			Want: false,
+		},
+		{
+			Name: "disable error - nothing specified",
+			Args: cmdtest.ITArgs{
+				Args:      []string{},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "IP or alias expected",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestAliasDisableCommand", func() *cobra.Command { return NewCmdAliasDisable() })
+}
+
+func TestAliasEnableCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "enable - by ip",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"192.168.100.102"},
+				InputFile:  "testdata/disabled-entries.txt",
+				OutputFile: "testdata/toggle/enable__by_ip__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "enable - by alias",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"transactions.example.com"},
+				InputFile:  "testdata/disabled-entries.txt",
+				OutputFile: "testdata/toggle/enable__by_alias__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "enable - block",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-b", "3"},
+				InputFile:  "testdata/disabled-entries.txt",
+				OutputFile: "testdata/toggle/enable__block__result.txt",
+			},
+			Want: true,
+		},
+
+		// errors cases
+		{
+			Name: "enable error - not found",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"users.example.com"},
+				InputFile: "testdata/disabled-entries.txt",
+				ErrorText: "alias not found",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestAliasEnableCommand", func() *cobra.Command { return NewCmdAliasEnable() })
+}
@@ -0,0 +1,200 @@
+package alias
+
+import (
+	"fmt"
+
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/0xcfff/hostsctl/iptools"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	scopeBlock = "block"
+	scopeAll   = "all"
+)
+
+// Numbers of aliases changed by upsert
+type upsertSummary struct {
+	added     int
+	updated   int
+	unchanged int
+	removed   int
+}
+
+func (s *upsertSummary) String() string {
+	return fmt.Sprintf("%d added, %d updated, %d unchanged, %d removed", s.added, s.updated, s.unchanged, s.removed)
+}
+
+// Makes each alias of the entries point to the entry IP within the scope blocks,
+// aliases not found in the scope are added to the target block.
+// System aliases are changed or removed only if forced
+func performUpsert(scope []*dom.IPAliasesBlock, target *dom.IPAliasesBlock, entries []*dom.IPAliasesEntry, prune bool, system *iptools.SystemAliasCatalog, force bool) (*upsertSummary, error) {
+	summary := &upsertSummary{}
+	for _, ent := range entries {
+		added := make([]string, 0)
+		for _, alias := range ent.Aliases() {
+			found, changed, err := upsertAlias(scope, ent, alias, system, force)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case !found:
+				added = append(added, alias)
+				summary.added++
+			case changed:
+				summary.updated++
+			default:
+				summary.unchanged++
+			}
+		}
+		if len(added) > 0 {
+			newEnt := dom.NewIPAliasesEntry(ent.IP())
+			newEnt.SetAliases(added)
+			newEnt.SetNote(ent.Note())
+			newEnt.SetDisabled(ent.Disabled())
+			target.AddEntry(newEnt)
+		}
+	}
+	if prune {
+		removed, err := pruneBlock(target, entries, system, force)
+		if err != nil {
+			return nil, err
+		}
+		summary.removed = removed
+	}
+	return summary, nil
+}
+
+// Updates scope entries with the alias to match the upserted entry, extra occurrences
+// of the alias are removed. Entries of the other IP family are separate mappings and left as is.
+// Returns whether the alias was found and whether anything was changed
+func upsertAlias(scope []*dom.IPAliasesBlock, upserted *dom.IPAliasesEntry, alias string, system *iptools.SystemAliasCatalog, force bool) (bool, bool, error) {
+	type occurrence struct {
+		block *dom.IPAliasesBlock
+		entry *dom.IPAliasesEntry
+	}
+	occurrences := make([]occurrence, 0)
+	var kept *dom.IPAliasesEntry
+	var keptBlock *dom.IPAliasesBlock
+	for _, block := range scope {
+		for _, ent := range block.AliasEntriesByAlias(alias) {
+			if !iptools.SameFamily(ent.IP(), upserted.IP()) {
+				continue
+			}
+			occurrences = append(occurrences, occurrence{block, ent})
+			if kept == nil && iptools.EqualAddr(ent.IP(), upserted.IP()) && ent.Disabled() == upserted.Disabled() {
+				kept, keptBlock = ent, block
+			}
+		}
+	}
+	if len(occurrences) == 0 {
+		return false, false, nil
+	}
+
+	systemCount := 0
+	for _, o := range occurrences {
+		if o.entry != kept && system.IsSystemAlias(o.entry.IP(), alias) {
+			systemCount++
+		}
+	}
+	if systemCount > 0 && !force {
+		return true, false, fmt.Errorf("%d of %d entries is system", systemCount, len(occurrences))
+	}
+
+	changed := false
+	for _, o := range occurrences {
+		if o.entry == kept {
+			continue
+		}
+		if kept == nil {
+			kept, keptBlock = repointAlias(o.block, o.entry, upserted, alias), o.block
+		} else {
+			removeAlias(o.block, o.entry, alias)
+		}
+		changed = true
+	}
+	if upserted.Note() != "" && kept.Note() != upserted.Note() {
+		kept = splitForeignAliases(keptBlock, kept, upserted, alias)
+		kept.SetNote(upserted.Note())
+		changed = true
+	}
+	return true, changed, nil
+}
+
+// Moves the alias into a separate line next to the entry if the entry has aliases
+// which are not upserted, so the new note (e.g. @expires) doesn't apply to them
+func splitForeignAliases(block *dom.IPAliasesBlock, entry *dom.IPAliasesEntry, upserted *dom.IPAliasesEntry, alias string) *dom.IPAliasesEntry {
+	foreign := slices.IndexFunc(entry.Aliases(), func(a string) bool { return !slices.Contains(upserted.Aliases(), a) })
+	if foreign < 0 {
+		return entry
+	}
+	entry.RemoveAlias(alias)
+	split := dom.NewIPAliasesEntry(entry.IP())
+	split.AddAlias(alias)
+	split.SetDisabled(entry.Disabled())
+	block.InsertEntry(block.IndexOfEntry(entry)+1, split)
+	return split
+}
+
+// Changes IP of the entry in place, or splits the alias into a separate line next to the entry
+// if the entry has other aliases
+func repointAlias(block *dom.IPAliasesBlock, entry *dom.IPAliasesEntry, upserted *dom.IPAliasesEntry, alias string) *dom.IPAliasesEntry {
+	if len(entry.Aliases()) == 1 {
+		entry.SetIP(upserted.IP())
+		entry.SetDisabled(upserted.Disabled())
+		return entry
+	}
+	entry.RemoveAlias(alias)
+	split := dom.NewIPAliasesEntry(upserted.IP())
+	split.AddAlias(alias)
+	split.SetNote(entry.Note())
+	split.SetDisabled(upserted.Disabled())
+	block.InsertEntry(block.IndexOfEntry(entry)+1, split)
+	return split
+}
+
+func removeAlias(block *dom.IPAliasesBlock, entry *dom.IPAliasesEntry, alias string) {
+	if len(entry.Aliases()) <= 1 {
+		block.RemoveEntry(entry)
+	} else {
+		entry.RemoveAlias(alias)
+	}
+}
+
+// Removes IP/alias pairs of the block which are not described by the entries,
+// returns number of removed aliases. System aliases are removed only if forced
+func pruneBlock(block *dom.IPAliasesBlock, entries []*dom.IPAliasesEntry, system *iptools.SystemAliasCatalog, force bool) (int, error) {
+	wanted := func(ip string, alias string) bool {
+		for _, ent := range entries {
+			if iptools.EqualAddr(ent.IP(), ip) && slices.Contains(ent.Aliases(), alias) {
+				return true
+			}
+		}
+		return false
+	}
+
+	type pair struct {
+		entry *dom.IPAliasesEntry
+		alias string
+	}
+	unwanted := make([]pair, 0)
+	systemCount := 0
+	for _, ent := range block.AliasEntries() {
+		for _, alias := range ent.Aliases() {
+			if !wanted(ent.IP(), alias) {
+				unwanted = append(unwanted, pair{ent, alias})
+				if system.IsSystemAlias(ent.IP(), alias) {
+					systemCount++
+				}
+			}
+		}
+	}
+	if systemCount > 0 && !force {
+		return 0, fmt.Errorf("%d of %d entries is system", systemCount, len(unwanted))
+	}
+
+	for _, p := range unwanted {
+		removeAlias(block, p.entry, p.alias)
+	}
+	return len(unwanted), nil
+}
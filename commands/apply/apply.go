@@ -0,0 +1,295 @@
+package apply
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/0xcfff/hostsctl/iptools"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+)
+
+const stdinFile = "-"
+
+// Outcome of applying a manifest block
+type blockResult string
+
+const (
+	blockCreated    blockResult = "created"
+	blockConfigured blockResult = "configured"
+	blockUnchanged  blockResult = "unchanged"
+)
+
+type ApplyOptions struct {
+	command  *cobra.Command
+	file     string
+	prune    bool
+	force    bool
+	manifest *ManifestModel
+	dryRun   common.DryRunOptions
+}
+
+func NewCmdApply() *cobra.Command {
+
+	opt := &ApplyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "apply (-f|--filename)=file",
+		Short: fmt.Sprintf("Applies IP aliases blocks described by a manifest to %s file", hosts.EtcHosts.Path()),
+		Long: fmt.Sprintf(`Applies IP aliases blocks described by a YAML or JSON manifest to %s file.
+
+Blocks are matched by name, missing blocks are created, ids and notes of existing blocks
+are updated and their entries are ordered as in the manifest. Entries not described
+by the manifest are kept, unless --prune is specified, aliases described by the manifest
+are removed from them. Blocks not mentioned in the manifest are not changed.
+
+Manifest example:
+  blocks:
+  - id: 20
+    name: k8s-local
+    note: Local cluster nodes
+    entries:
+    - ip: 10.0.0.1
+      aliases: [node1.k8s.local, node1]
+    - ip: 10.0.0.2
+      aliases: [node2.k8s.local]
+      comment: under maintenance
+      disabled: true`, hosts.EtcHosts.Path()),
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.RunCliCommand(opt, cmd, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opt.file, "filename", "f", opt.file, "Manifest file, use - to read the manifest from stdin")
+	cmd.Flags().BoolVar(&opt.prune, "prune", opt.prune, "Remove entries of the manifest blocks which are not described in the manifest")
+	cmd.Flags().BoolVar(&opt.force, "force", opt.force, "Change the manifest blocks even if they are locked")
+
+	common.AddDryRunFlags(cmd, &opt.dryRun)
+
+	return cmd
+}
+
+func (opt *ApplyOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
+func (opt *ApplyOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+	return nil
+}
+
+func (opt *ApplyOptions) Validate() error {
+	args := opt.command.Flags().Args()
+	if len(args) > 0 {
+		return common.ErrTooManyArguments
+	}
+	if opt.file == "" {
+		return fmt.Errorf("manifest file is not specified; %w", common.ErrNotEnoughArguments)
+	}
+
+	var err error
+	opt.manifest, err = opt.readManifest()
+	if err != nil {
+		return err
+	}
+	return opt.manifest.Validate()
+}
+
+func (opt *ApplyOptions) Execute() error {
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
+	doc, err := src.Load()
+	cobra.CheckErr(err)
+	locked := common.FindLockedBlocks(doc)
+
+	results := make([]blockResult, 0, len(opt.manifest.Blocks))
+	for _, mb := range opt.manifest.Blocks {
+		res, err := applyBlock(doc, mb, opt.prune)
+		cobra.CheckErr(err)
+		results = append(results, res)
+	}
+
+	err = common.CheckLockedBlocks(doc, locked, opt.force)
+	cobra.CheckErr(err)
+
+	doc.Normalize()
+
+	err = src.Save(doc, dom.FmtKeep)
+	cobra.CheckErr(err)
+
+	out := opt.command.OutOrStdout()
+	for i, mb := range opt.manifest.Blocks {
+		fmt.Fprintf(out, "block %s %s\n", mb.Name, results[i])
+	}
+
+	return nil
+}
+
+func (opt *ApplyOptions) readManifest() (*ManifestModel, error) {
+	if opt.file == stdinFile {
+		return ReadManifest(opt.command.InOrStdin())
+	}
+
+	fs := common.FileSystem(opt.command.Context())
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+
+	f, err := fs.Open(opt.file)
+	if err != nil {
+		return nil, fmt.Errorf("can't open manifest %s, %w", opt.file, err)
+	}
+	defer f.Close()
+	return ReadManifest(f)
+}
+
+// Converges the document block to the manifest block, creates the block if it does not exist
+func applyBlock(doc *dom.Document, mb *ManifestBlockModel, prune bool) (blockResult, error) {
+	block, err := findManifestBlock(doc, mb)
+	if err != nil {
+		return "", err
+	}
+
+	result := blockConfigured
+	if block == nil {
+		block = dom.NewIPAliasesBlock()
+		block.SetName(mb.Name)
+		doc.AddBlock(block)
+		result = blockCreated
+	}
+
+	changed := false
+	if mb.Id != nil && block.Id() != *mb.Id {
+		// blocks with auto assigned ids are checked too, they would be renumbered otherwise
+		for _, b := range doc.IPBlocks() {
+			if b != block && b.Id() == *mb.Id {
+				return "", fmt.Errorf("block with id %d already exists; %w", *mb.Id, common.ErrEntryAlreadyExists)
+			}
+		}
+		block.SetId(*mb.Id)
+		changed = true
+	}
+	if block.Name() != mb.Name {
+		block.SetName(mb.Name)
+		changed = true
+	}
+	if block.Note() != mb.Note {
+		block.SetNote(mb.Note)
+		changed = true
+	}
+	if applyEntries(block, mb, prune) {
+		changed = true
+	}
+
+	if result == blockConfigured && !changed {
+		result = blockUnchanged
+	}
+	return result, nil
+}
+
+func findManifestBlock(doc *dom.Document, mb *ManifestBlockModel) (*dom.IPAliasesBlock, error) {
+	found := make([]*dom.IPAliasesBlock, 0)
+	for _, b := range doc.IPBlocks() {
+		if strings.EqualFold(b.Name(), mb.Name) {
+			found = append(found, b)
+		}
+	}
+	if len(found) > 1 {
+		return nil, fmt.Errorf("multiple blocks named %s found: %w", mb.Name, common.ErrTooManyEntries)
+	}
+	if len(found) == 1 {
+		return found[0], nil
+	}
+	return nil, nil
+}
+
+// Updates block entries to match the manifest, returns true if the block is changed
+func applyEntries(block *dom.IPAliasesBlock, mb *ManifestBlockModel, prune bool) bool {
+	existing := block.AliasEntries()
+	used := make(map[*dom.IPAliasesEntry]bool)
+	changed := false
+
+	ordered := make([]*dom.IPAliasesEntry, 0, len(existing))
+	declared := make(map[string]bool)
+	for _, me := range mb.Entries {
+		for _, a := range me.Aliases {
+			declared[strings.ToLower(a)] = true
+		}
+
+		ent := findEntry(existing, used, me)
+		if ent == nil {
+			ent = dom.NewIPAliasesEntry(me.IP)
+			changed = true
+		}
+		used[ent] = true
+
+		if ent.SetAliases(me.Aliases) {
+			changed = true
+		}
+		if ent.Note() != me.Comment {
+			ent.SetNote(me.Comment)
+			changed = true
+		}
+		if ent.Disabled() != me.Disabled {
+			ent.SetDisabled(me.Disabled)
+			changed = true
+		}
+		ordered = append(ordered, ent)
+	}
+
+	// entries not described by the manifest lose the aliases the manifest owns
+	for _, ent := range existing {
+		if used[ent] {
+			continue
+		}
+		if prune {
+			changed = true
+			continue
+		}
+		for _, a := range ent.Aliases() {
+			if declared[strings.ToLower(a)] {
+				ent.RemoveAlias(a)
+				changed = true
+			}
+		}
+		if len(ent.Aliases()) > 0 {
+			ordered = append(ordered, ent)
+		}
+	}
+
+	if !slices.Equal(ordered, existing) {
+		for _, ent := range block.Entries() {
+			block.RemoveEntry(ent)
+		}
+		for _, ent := range ordered {
+			block.AddEntry(ent)
+		}
+		changed = true
+	}
+	return changed
+}
+
+// Finds unused entry with the same IP in any notation, entries with the same aliases are preferred
+func findEntry(entries []*dom.IPAliasesEntry, used map[*dom.IPAliasesEntry]bool, me *ManifestEntryModel) *dom.IPAliasesEntry {
+	var sameIP *dom.IPAliasesEntry
+	for _, ent := range entries {
+		if used[ent] || !iptools.EqualAddr(ent.IP(), me.IP) {
+			continue
+		}
+		if slices.Equal(ent.Aliases(), me.Aliases) {
+			return ent
+		}
+		if sameIP == nil {
+			sameIP = ent
+		}
+	}
+	return sameIP
+}
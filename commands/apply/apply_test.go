@@ -0,0 +1,153 @@
+package apply
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestApplyCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "apply - create and update blocks",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-f", "/tmp/manifest.yaml"},
+				InputFile:  "testdata/five-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/manifest.yaml": "testdata/apply/manifest.yaml"},
+				OutputFile: "testdata/apply/apply__five_blocks__result.txt",
+				Stdout:     "block pet-prj1 configured\nblock k8s-local created\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "apply - already applied",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-f", "/tmp/manifest.yaml"},
+				InputFile:  "testdata/apply/apply__five_blocks__result.txt",
+				ExtraFiles: map[string]string{"/tmp/manifest.yaml": "testdata/apply/manifest.yaml"},
+				OutputFile: "testdata/apply/apply__five_blocks__result.txt",
+				Stdout:     "block pet-prj1 unchanged\nblock k8s-local unchanged\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "apply - keep entries not in manifest",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-f", "/tmp/manifest.yaml"},
+				InputFile:  "testdata/five-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/manifest.yaml": "testdata/apply/manifest-prune.yaml"},
+				OutputFile: "testdata/apply/apply_no_prune__five_blocks__result.txt",
+				Stdout:     "block pet-prj2 configured\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "apply - prune entries not in manifest",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-f", "/tmp/manifest.yaml", "--prune"},
+				InputFile:  "testdata/five-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/manifest.yaml": "testdata/apply/manifest-prune.yaml"},
+				OutputFile: "testdata/apply/apply_prune__five_blocks__result.txt",
+				Stdout:     "block pet-prj2 configured\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "apply - already pruned",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-f", "/tmp/manifest.yaml", "--prune"},
+				InputFile:  "testdata/apply/apply_prune__five_blocks__result.txt",
+				ExtraFiles: map[string]string{"/tmp/manifest.yaml": "testdata/apply/manifest-prune.yaml"},
+				OutputFile: "testdata/apply/apply_prune__five_blocks__result.txt",
+				Stdout:     "block pet-prj2 unchanged\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "apply - json manifest from stdin",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-f", "-", "--prune"},
+				Stdin:      `{"blocks": [{"name": "pet-prj2", "note": "My pet project 2", "entries": [{"ip": "192.168.100.52", "aliases": ["orders.example.com"]}, {"ip": "192.168.100.55", "aliases": ["reports.example.com"]}]}]}`,
+				InputFile:  "testdata/five-blocks.txt",
+				OutputFile: "testdata/apply/apply_prune__five_blocks__result.txt",
+				Stdout:     "block pet-prj2 configured\n",
+			},
+			Want: true,
+		},
+
+		// errors cases
+		{
+			Name: "apply - other IP notation",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-f", "/tmp/manifest.yaml"},
+				InputFile:  "testdata/apply/k8s-local-ipv6.txt",
+				ExtraFiles: map[string]string{"/tmp/manifest.yaml": "testdata/apply/manifest-ipv6.yaml"},
+				OutputFile: "testdata/apply/k8s-local-ipv6.txt",
+				Stdout:     "block k8s-local unchanged\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "error - manifest not specified",
+			Args: cmdtest.ITArgs{
+				Args:      []string{},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "manifest file is not specified",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - invalid IP",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-f", "/tmp/manifest.yaml"},
+				InputFile:  "testdata/five-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/manifest.yaml": "testdata/apply/manifest-invalid-ip.yaml"},
+				ErrorText:  "block pet-prj2 has invalid IP 192.168.100.520",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - unknown field",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-f", "/tmp/manifest.yaml"},
+				InputFile:  "testdata/five-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/manifest.yaml": "testdata/apply/manifest-unknown-field.yaml"},
+				ErrorText:  "field address not found",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - id used by another block",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-f", "/tmp/manifest.yaml"},
+				InputFile:  "testdata/five-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/manifest.yaml": "testdata/apply/manifest-id-conflict.yaml"},
+				ErrorText:  "block with id 18 already exists",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - id auto assigned to another block",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-f", "/tmp/manifest.yaml"},
+				InputFile:  "testdata/five-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/manifest.yaml": "testdata/apply/manifest-auto-id-conflict.yaml"},
+				ErrorText:  "block with id 2 already exists",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - ambiguous block",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-f", "/tmp/manifest.yaml"},
+				InputFile:  "testdata/six-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/manifest.yaml": "testdata/apply/manifest.yaml"},
+				ErrorText:  "multiple blocks named pet-prj1 found",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestApplyCommand", func() *cobra.Command { return NewCmdApply() })
+}
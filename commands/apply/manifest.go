@@ -0,0 +1,87 @@
+package apply
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/iptools"
+	"gopkg.in/yaml.v3"
+)
+
+// Desired state of IP aliases blocks
+type ManifestModel struct {
+	Blocks []*ManifestBlockModel `json:"blocks" yaml:"blocks"`
+}
+
+type ManifestBlockModel struct {
+	Id      *int                  `json:"id,omitempty"   yaml:"id,omitempty"`
+	Name    string                `json:"name"           yaml:"name"`
+	Note    string                `json:"note,omitempty" yaml:"note,omitempty"`
+	Entries []*ManifestEntryModel `json:"entries"        yaml:"entries"`
+}
+
+type ManifestEntryModel struct {
+	IP       string   `json:"ip"                 yaml:"ip"`
+	Aliases  []string `json:"aliases"            yaml:"aliases"`
+	Comment  string   `json:"comment,omitempty"  yaml:"comment,omitempty"`
+	Disabled bool     `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+}
+
+// Reads YAML or JSON manifest, unknown fields are not allowed
+func ReadManifest(r io.Reader) (*ManifestModel, error) {
+	m := &ManifestModel{}
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	err := dec.Decode(m)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("can't parse manifest, %w", err)
+	}
+	return m, nil
+}
+
+// Checks the manifest describes each block once and all IPs and aliases are valid,
+// internationalized aliases are converted to punycode
+func (m *ManifestModel) Validate() error {
+	names := make(map[string]bool)
+	ids := make(map[int]bool)
+	for i, b := range m.Blocks {
+		if b.Name == "" {
+			return fmt.Errorf("block #%d has no name; %w", i+1, common.ErrWrongArgumentValue)
+		}
+		name := strings.ToLower(b.Name)
+		if names[name] {
+			return fmt.Errorf("block %s is defined more than once; %w", b.Name, common.ErrWrongArgumentValue)
+		}
+		names[name] = true
+
+		if b.Id != nil {
+			if *b.Id < 0 {
+				return fmt.Errorf("block %s has negative id %d; %w", b.Name, *b.Id, common.ErrWrongArgumentValue)
+			}
+			if ids[*b.Id] {
+				return fmt.Errorf("block id %d is used more than once; %w", *b.Id, common.ErrWrongArgumentValue)
+			}
+			ids[*b.Id] = true
+		}
+
+		for _, e := range b.Entries {
+			if !iptools.IsIP(e.IP) {
+				return fmt.Errorf("block %s has invalid IP %s; %w", b.Name, e.IP, common.ErrWrongArgumentValue)
+			}
+			if len(e.Aliases) == 0 {
+				return fmt.Errorf("block %s has no aliases for IP %s; %w", b.Name, e.IP, common.ErrWrongArgumentValue)
+			}
+			for j, a := range e.Aliases {
+				ascii, err := iptools.ToASCIIHostname(a)
+				if err != nil {
+					return fmt.Errorf("block %s has invalid alias %s; %w", b.Name, a, common.ErrWrongArgumentValue)
+				}
+				e.Aliases[j] = ascii
+			}
+		}
+	}
+	return nil
+}
@@ -3,7 +3,9 @@ package block
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/0xcfff/hostsctl/commands/common"
 	"github.com/0xcfff/hostsctl/hosts"
@@ -15,12 +17,18 @@ const (
 	emptyId = -1
 )
 
+var (
+	rxLabelKey = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_./-]*$`)
+)
+
 type BlockAddOptions struct {
 	command   *cobra.Command
 	blockId   int
 	blockName string
 	comment   string
+	labels    []string
 	force     bool
+	dryRun    common.DryRunOptions
 }
 
 func NewCmdBlockAdd() *cobra.Command {
@@ -33,20 +41,25 @@ func NewCmdBlockAdd() *cobra.Command {
 		Short: fmt.Sprintf("Adds IP aliases block to %s file", hosts.EtcHosts.Path()),
 		Args:  cobra.ArbitraryArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			cobra.CheckErr(opt.Complete(cmd, args))
-			cobra.CheckErr(opt.Validate())
-			cobra.CheckErr(opt.Execute())
+			common.RunCliCommand(opt, cmd, args)
 		},
 	}
 
 	cmd.Flags().StringVarP(&opt.blockName, "name", "t", opt.blockName, "Block name")
 	cmd.Flags().IntVarP(&opt.blockId, "id", "n", opt.blockId, "Block id")
 	cmd.Flags().StringVarP(&opt.comment, "comment", "c", opt.comment, "Block comment")
+	cmd.Flags().StringArrayVarP(&opt.labels, "label", "l", opt.labels, "Block metadata as key=value, written to the block header as '# @key: value'")
 	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Do not fail if the block already exists, just update it with provided data")
 
+	common.AddDryRunFlags(cmd, &opt.dryRun)
+
 	return cmd
 }
 
+func (opt *BlockAddOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
 func (opt *BlockAddOptions) Complete(cmd *cobra.Command, args []string) error {
 
 	opt.command = cmd
@@ -75,11 +88,19 @@ func (opt *BlockAddOptions) Complete(cmd *cobra.Command, args []string) error {
 }
 
 func (opt *BlockAddOptions) Validate() error {
+	for _, l := range opt.labels {
+		if _, _, err := parseLabel(l); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 func (opt *BlockAddOptions) Execute() error {
-	src := hosts.NewSource(hosts.EtcHosts.Path(), common.FileSystem(opt.command.Context()))
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
 	doc, err := src.Load()
 	cobra.CheckErr(err)
 
@@ -117,9 +138,29 @@ func updateBlock(block *dom.IPAliasesBlock, opts *BlockAddOptions) error {
 	if opts.comment != "" {
 		block.SetNote(opts.comment)
 	}
+	for _, l := range opts.labels {
+		key, value, err := parseLabel(l)
+		if err != nil {
+			return err
+		}
+		block.SetLabel(key, value)
+	}
 	return nil
 }
 
+// Parses key=value label, the key may contain letters, digits and "_./-"
+func parseLabel(label string) (string, string, error) {
+	key, value, found := strings.Cut(label, "=")
+	key = strings.TrimSpace(key)
+	if !found || !rxLabelKey.MatchString(key) {
+		return "", "", fmt.Errorf("label %q is not in key=value format; %w", label, common.ErrWrongArgumentValue)
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return "", "", fmt.Errorf("label %s value must be a single line; %w", key, common.ErrWrongArgumentValue)
+	}
+	return key, strings.TrimSpace(value), nil
+}
+
 func createBlock(opts *BlockAddOptions) (*dom.IPAliasesBlock, error) {
 	block := dom.NewIPAliasesBlock()
 	err := updateBlock(block, opts)
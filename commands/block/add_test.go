@@ -45,6 +45,15 @@ func TestBlockAddCommand(t *testing.T) {
 			},
 			Want: true,
 		},
+		{
+			Name: "add - labels",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"k8s-local", "-c", "Local cluster", "--label", "owner=platform-team", "-l", "source=kubectl"},
+				InputFile:  "testdata/empty.txt",
+				OutputFile: "testdata/add/add__labels__result.txt",
+			},
+			Want: true,
+		},
 
 		// Force flag
 		{
@@ -71,6 +80,15 @@ func TestBlockAddCommand(t *testing.T) {
 			},
 			Want: true,
 		},
+		{
+			Name: "add force - update labels",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"k8s-local", "--label", "source=helm", "--label", "env=dev", "--force"},
+				InputFile:  "testdata/labeled-blocks.txt",
+				OutputFile: "testdata/add/add_force__update_labels__result.txt",
+			},
+			Want: true,
+		},
 
 		// Error cases
 		{
@@ -109,6 +127,24 @@ func TestBlockAddCommand(t *testing.T) {
 			},
 			Want: false,
 		},
+		{
+			Name: "error - label without value",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"k8s-local", "--label", "owner"},
+				InputFile: "testdata/empty.txt",
+				ErrorText: "label \"owner\" is not in key=value format",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - label with invalid key",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"k8s-local", "--label", "@owner=me"},
+				InputFile: "testdata/empty.txt",
+				ErrorText: "is not in key=value format",
+			},
+			Want: false,
+		},
 	}
 	cmdtest.RunIntergationTests(t, tests, "TestBlockAddCommand", func() *cobra.Command { return NewCmdBlockAdd() })
 }
@@ -14,6 +14,13 @@ func NewCmdBlock() *cobra.Command {
 	cmd.AddCommand(NewCmdBlockAdd())
 	cmd.AddCommand(NewCmdBlockDelete())
 	cmd.AddCommand(NewCmdBlockClear())
+	cmd.AddCommand(NewCmdBlockExport())
+	cmd.AddCommand(NewCmdBlockImport())
+	cmd.AddCommand(NewCmdBlockRename())
+	cmd.AddCommand(NewCmdBlockSetId())
+	cmd.AddCommand(NewCmdBlockMove())
+	cmd.AddCommand(NewCmdBlockLock())
+	cmd.AddCommand(NewCmdBlockUnlock())
 
 	return cmd
 }
@@ -8,7 +8,6 @@ import (
 	"github.com/0xcfff/hostsctl/commands/common"
 	"github.com/0xcfff/hostsctl/hosts"
 	"github.com/0xcfff/hostsctl/hosts/dom"
-	"github.com/0xcfff/hostsctl/iptools"
 	"github.com/spf13/cobra"
 )
 
@@ -17,6 +16,7 @@ type BlockClearOptions struct {
 	blockId   int
 	blockName string
 	force     bool
+	dryRun    common.DryRunOptions
 }
 
 func NewCmdBlockClear() *cobra.Command {
@@ -29,19 +29,23 @@ func NewCmdBlockClear() *cobra.Command {
 		Short: fmt.Sprintf("Clears IP aliases block in %s file", hosts.EtcHosts.Path()),
 		Args:  cobra.ArbitraryArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			cobra.CheckErr(opt.Complete(cmd, args))
-			cobra.CheckErr(opt.Validate())
-			cobra.CheckErr(opt.Execute())
+			common.RunCliCommand(opt, cmd, args)
 		},
 	}
 
 	cmd.Flags().StringVarP(&opt.blockName, "name", "t", opt.blockName, "Block name")
 	cmd.Flags().IntVarP(&opt.blockId, "id", "n", opt.blockId, "Block id")
-	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Clear the block even if it has system aliases")
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Clear the block even if it has system aliases or is locked")
+
+	common.AddDryRunFlags(cmd, &opt.dryRun)
 
 	return cmd
 }
 
+func (opt *BlockClearOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
 func (opt *BlockClearOptions) Complete(cmd *cobra.Command, args []string) error {
 
 	opt.command = cmd
@@ -74,9 +78,13 @@ func (opt *BlockClearOptions) Validate() error {
 }
 
 func (opt *BlockClearOptions) Execute() error {
-	src := hosts.NewSource(hosts.EtcHosts.Path(), common.FileSystem(opt.command.Context()))
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
 	doc, err := src.Load()
 	cobra.CheckErr(err)
+	locked := common.FindLockedBlocks(doc)
 
 	block, err := findTargetBlockForClear(doc, opt)
 	cobra.CheckErr(err)
@@ -93,6 +101,9 @@ func (opt *BlockClearOptions) Execute() error {
 		cobra.CheckErr(err)
 	}
 
+	err = common.CheckLockedBlocks(doc, locked, opt.force)
+	cobra.CheckErr(err)
+
 	doc.Normalize()
 
 	err = src.Save(doc, dom.FmtKeep)
@@ -106,11 +117,12 @@ func validateClear(block *dom.IPAliasesBlock, opts *BlockClearOptions) error {
 		return nil
 	}
 
+	system := common.SystemAliases(opts.command.Context())
 	for _, ent := range block.AliasEntries() {
 		ip := ent.IP()
 		for _, alias := range ent.Aliases() {
-			if iptools.IsSystemAlias(ip, alias) {
-				return errors.New("the block has system aliases")
+			if sa, ok := system.Lookup(ip, alias); ok {
+				return fmt.Errorf("the block has system aliases, %s %s (%s)", ip, alias, sa.Source)
 			}
 		}
 	}
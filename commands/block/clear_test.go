@@ -22,6 +22,18 @@ func TestBlockClearCommand(t *testing.T) {
 			},
 			Want: true,
 		},
+		{
+			Name: "clear - dry run",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"15", "--dry-run"},
+				Stdin:      "",
+				InputFile:  "testdata/five-blocks.txt",
+				OutputFile: "testdata/five-blocks.txt",
+				StdoutFile: "testdata/clear/clear_dry_run__by_id__output.txt",
+				ErrorText:  "",
+			},
+			Want: true,
+		},
 		{
 			Name: "clear - by name",
 			Args: cmdtest.ITArgs{
@@ -94,6 +106,33 @@ func TestBlockClearCommand(t *testing.T) {
 			},
 			Want: false,
 		},
+		{
+			Name: "clear error - system reason",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"2"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "the block has system aliases, ::1 ip6-localhost (common)",
+			},
+			Want: false,
+		},
+		{
+			Name: "clear locked - forced",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"corp-vpn", "--force"},
+				InputFile:  "testdata/locked-blocks.txt",
+				OutputFile: "testdata/clear/clear_locked_forced__by_name__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "clear error - locked",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"corp-vpn"},
+				InputFile: "testdata/locked-blocks.txt",
+				ErrorText: "block [3] corp-vpn is locked, use --force to change it",
+			},
+			Want: false,
+		},
 	}
 
 	cmdtest.RunIntergationTests(t, tests, "TestBlockClearCommand", func() *cobra.Command { return NewCmdBlockClear() })
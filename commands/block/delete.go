@@ -9,7 +9,6 @@ import (
 	"github.com/0xcfff/hostsctl/hosts"
 	"github.com/0xcfff/hostsctl/hosts/dom"
 	"github.com/spf13/cobra"
-	"golang.org/x/exp/slices"
 )
 
 type BlockDeleteOptions struct {
@@ -17,6 +16,7 @@ type BlockDeleteOptions struct {
 	blockId   int
 	blockName string
 	force     bool
+	dryRun    common.DryRunOptions
 }
 
 func NewCmdBlockDelete() *cobra.Command {
@@ -30,17 +30,21 @@ func NewCmdBlockDelete() *cobra.Command {
 		Aliases: []string{"remove", "rm"},
 		Args:    cobra.ArbitraryArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			cobra.CheckErr(opt.Complete(cmd, args))
-			cobra.CheckErr(opt.Validate())
-			cobra.CheckErr(opt.Execute())
+			common.RunCliCommand(opt, cmd, args)
 		},
 	}
 
-	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Force command to succeed even if IP alias is not found")
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Force command to succeed even if IP alias is not found or the block is locked")
+
+	common.AddDryRunFlags(cmd, &opt.dryRun)
 
 	return cmd
 }
 
+func (opt *BlockDeleteOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
 func (opt *BlockDeleteOptions) Complete(cmd *cobra.Command, args []string) error {
 
 	opt.command = cmd
@@ -74,14 +78,20 @@ func (opt *BlockDeleteOptions) Validate() error {
 
 func (opt *BlockDeleteOptions) Execute() error {
 
-	src := hosts.NewSource(hosts.EtcHosts.Path(), common.FileSystem(opt.command.Context()))
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
 	doc, err := src.Load()
 	cobra.CheckErr(err)
+	locked := common.FindLockedBlocks(doc)
 
 	targerBlocks, err := findTargetBlockForDelete(doc, opt)
 	cobra.CheckErr(err)
 
-	err = deleteBlocks(doc, targerBlocks)
+	common.DeleteBlocks(doc, targerBlocks)
+
+	err = common.CheckLockedBlocks(doc, locked, opt.force)
 	cobra.CheckErr(err)
 
 	doc.Normalize()
@@ -113,24 +123,3 @@ func findTargetBlockForDelete(doc *dom.Document, opt *BlockDeleteOptions) ([]*do
 
 	return selectedBlocks, nil
 }
-
-func deleteBlocks(doc *dom.Document, targerBlocks []*dom.IPAliasesBlock) error {
-	for _, b := range targerBlocks {
-		blocks := doc.Blocks()
-
-		var mainBlock, spaceBlock dom.Block
-
-		mainBlock = b
-		idx := slices.Index(blocks, mainBlock)
-		if len(blocks) > idx+1 {
-			spaceBlock = blocks[idx+1]
-		}
-
-		doc.DeleteBlock(mainBlock)
-		if spaceBlock != nil && spaceBlock.Type() == dom.Blanks {
-			doc.DeleteBlock(spaceBlock)
-		}
-	}
-
-	return nil
-}
@@ -145,6 +145,15 @@ func TestBlockDeleteCommand(t *testing.T) {
 			},
 			Want: false,
 		},
+		{
+			Name: "delete error - locked block",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"18"},
+				InputFile: "testdata/locked-blocks.txt",
+				ErrorText: "block [18] pet-prj3 is locked by ansible",
+			},
+			Want: false,
+		},
 	}
 
 	cmdtest.RunIntergationTests(t, tests, "TestBlockDeleteCommand", func() *cobra.Command { return NewCmdBlockDelete() })
@@ -0,0 +1,179 @@
+package block
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+type exportFormat int
+
+const (
+	efmtHosts exportFormat = iota
+	efmtJson  exportFormat = iota
+	efmtYaml  exportFormat = iota
+)
+
+const tfmtHosts = "hosts"
+
+var (
+	exportFormats = map[string]exportFormat{
+		"":              efmtHosts,
+		tfmtHosts:       efmtHosts,
+		common.TfmtJson: efmtJson,
+		common.TfmtYaml: efmtYaml,
+	}
+)
+
+type BlockExportOptions struct {
+	command      *cobra.Command
+	blockId      int
+	blockName    string
+	output       string
+	outputFormat exportFormat
+}
+
+func NewCmdBlockExport() *cobra.Command {
+
+	opt := &BlockExportOptions{}
+	opt.blockId = emptyId
+
+	cmd := &cobra.Command{
+		Use:   "export [id or name] [(-o|--output)=name]",
+		Short: fmt.Sprintf("Exports IP aliases block from %s file", hosts.EtcHosts.Path()),
+		Long: fmt.Sprintf(`Exports IP aliases block from %s file.
+
+The block header, note and all entries including disabled ones are exported,
+the output can be loaded into another hosts file with 'block import'`, hosts.EtcHosts.Path()),
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(opt.Complete(cmd, args))
+			cobra.CheckErr(opt.Validate())
+			cobra.CheckErr(opt.Execute())
+		},
+	}
+
+	cmd.Flags().StringVarP(&opt.blockName, "name", "t", opt.blockName, "Block name")
+	cmd.Flags().IntVarP(&opt.blockId, "id", "n", opt.blockId, "Block id")
+	cmd.Flags().StringVarP(&opt.output, "output", "o", opt.output, fmt.Sprintf("Output format. One of %s", strings.Join(maps.Keys(exportFormats), ",")))
+
+	return cmd
+}
+
+func (opt *BlockExportOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	var ok bool
+	opt.outputFormat, ok = exportFormats[opt.output]
+	if !ok {
+		return fmt.Errorf("value %v is not support; %w", opt.output, common.ErrNotSupportedOutputFormat)
+	}
+
+	parsedArgs := cmd.Flags().Args()
+	if len(args) > 1 {
+		return common.ErrTooManyArguments
+	}
+
+	if len(parsedArgs) == 1 {
+		blockIdOrName := parsedArgs[0]
+		if id, err := strconv.Atoi(blockIdOrName); err == nil {
+			if opt.blockId != emptyId {
+				return errors.New("block Id is provided twice")
+			}
+			opt.blockId = id
+		} else {
+			if opt.blockName != "" {
+				return errors.New("block Name is provided twice")
+			}
+			opt.blockName = blockIdOrName
+		}
+	}
+
+	return nil
+}
+
+func (opt *BlockExportOptions) Validate() error {
+	if opt.blockId == emptyId && opt.blockName == "" {
+		return fmt.Errorf("block id or name expected; %w", common.ErrNotEnoughArguments)
+	}
+	return nil
+}
+
+func (opt *BlockExportOptions) Execute() error {
+	src := common.HostsSource(opt.command.Context())
+	doc, err := src.Load()
+	cobra.CheckErr(err)
+
+	block, err := findTargetBlockForExport(doc, opt)
+	cobra.CheckErr(err)
+	if block == nil {
+		return common.ErrBlockNotFound
+	}
+
+	switch opt.outputFormat {
+	case efmtHosts:
+		err = writeBlockAsHosts(opt, block)
+	case efmtJson:
+		err = writeBlockAsJson(opt, block)
+	case efmtYaml:
+		err = writeBlockAsYaml(opt, block)
+	default:
+		panic("unknown output format")
+	}
+	cobra.CheckErr(err)
+
+	return nil
+}
+
+func writeBlockAsHosts(opt *BlockExportOptions, block *dom.IPAliasesBlock) error {
+	doc := dom.NewEmptyDocument()
+	doc.AddBlock(block)
+	out := opt.command.OutOrStdout()
+	err := dom.Write(out, doc, dom.FmtKeep)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out)
+	return nil
+}
+
+func writeBlockAsJson(opt *BlockExportOptions, block *dom.IPAliasesBlock) error {
+	buff, err := json.Marshal(NewBlockExportModel(block))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opt.command.OutOrStdout(), string(buff))
+	return nil
+}
+
+func writeBlockAsYaml(opt *BlockExportOptions, block *dom.IPAliasesBlock) error {
+	buff, err := yaml.Marshal(NewBlockExportModel(block))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opt.command.OutOrStdout(), string(buff))
+	return nil
+}
+
+func findTargetBlockForExport(doc *dom.Document, opt *BlockExportOptions) (*dom.IPAliasesBlock, error) {
+	selectedBlocks := doc.IPBlocksByIdentifiers(opt.blockId, opt.blockName)
+
+	blocksFound := len(selectedBlocks)
+	if blocksFound > 1 {
+		return nil, fmt.Errorf("multiple blocks found matching criteria: %w", common.ErrTooManyEntries)
+	} else if blocksFound == 1 {
+		return selectedBlocks[0], nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,87 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestBlockExportCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "export - by name",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"pet-prj2"},
+				InputFile:  "testdata/five-blocks.txt",
+				StdoutFile: "testdata/export/export__by_name__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "export - empty block",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"18"},
+				InputFile:  "testdata/five-blocks.txt",
+				StdoutFile: "testdata/export/export__empty_block__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "export json - by id",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"15", "-o", "json"},
+				InputFile:  "testdata/five-blocks.txt",
+				StdoutFile: "testdata/export/export_json__by_id__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "export yaml - by name",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--name", "pet-prj2", "-o", "yaml"},
+				InputFile:  "testdata/five-blocks.txt",
+				StdoutFile: "testdata/export/export_yaml__by_name__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "export - block not found",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"pet-prj9"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "block not found",
+			},
+			Want: false,
+		},
+		{
+			Name: "export - no block specified",
+			Args: cmdtest.ITArgs{
+				Args:      []string{},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "block id or name expected",
+			},
+			Want: false,
+		},
+		{
+			Name: "export - wrong format",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"15", "-o", "xml"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "value xml is not support",
+			},
+			Want: false,
+		},
+		{
+			Name: "export yaml - labels",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"k8s-local", "-o", "yaml"},
+				InputFile:  "testdata/labeled-blocks.txt",
+				StdoutFile: "testdata/export/export_yaml__labels__output.txt",
+			},
+			Want: true,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestBlockExportCommand", func() *cobra.Command { return NewCmdBlockExport() })
+}
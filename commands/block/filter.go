@@ -0,0 +1,61 @@
+package block
+
+import (
+	"strconv"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/0xcfff/hostsctl/iptools"
+)
+
+// Selects blocks to be listed
+type BlockFilter struct {
+	terms        []*common.FilterTerm
+	systemOnly   bool
+	noSystem     bool
+	disabledOnly bool
+	comment      string
+	system       *iptools.SystemAliasCatalog
+}
+
+// Returns true if the block satisfies all the filter conditions
+func (f *BlockFilter) Match(block *dom.IPAliasesBlock) bool {
+	if f == nil {
+		return true
+	}
+	if f.systemOnly || f.noSystem {
+		system := hasEntry(block, func(ent *dom.IPAliasesEntry, alias string) bool { return f.system.IsSystemAlias(ent.IP(), alias) })
+		if (f.systemOnly && !system) || (f.noSystem && system) {
+			return false
+		}
+	}
+	if f.disabledOnly && !hasEntry(block, func(ent *dom.IPAliasesEntry, alias string) bool { return ent.Disabled() }) {
+		return false
+	}
+	if f.comment != "" && !common.ContainsFold(block.Note(), f.comment) {
+		return false
+	}
+	if len(f.terms) == 0 {
+		return true
+	}
+	for _, t := range f.terms {
+		if t.IsAddress() && hasEntry(block, func(ent *dom.IPAliasesEntry, alias string) bool { return t.MatchIP(ent.IP()) }) {
+			return true
+		}
+		if !t.IsAddress() && (t.MatchName(block.Name()) || t.MatchName(strconv.Itoa(block.Id()))) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEntry(block *dom.IPAliasesBlock, match func(ent *dom.IPAliasesEntry, alias string) bool) bool {
+	for _, ent := range block.AliasEntries() {
+		for _, alias := range ent.Aliases() {
+			if match(ent, alias) {
+				return true
+			}
+		}
+	}
+	return false
+}
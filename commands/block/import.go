@@ -0,0 +1,298 @@
+package block
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	stdinFile  = "-"
+	formatAuto = "auto"
+)
+
+// What to do if an imported block has the same name or id as an existing one
+type conflictPolicy string
+
+const (
+	conflictFail     conflictPolicy = "fail"
+	conflictSkip     conflictPolicy = "skip"
+	conflictReplace  conflictPolicy = "replace"
+	conflictRenumber conflictPolicy = "renumber"
+)
+
+var (
+	importFormats = map[string]exportFormat{
+		formatAuto:      efmtHosts,
+		tfmtHosts:       efmtHosts,
+		common.TfmtJson: efmtJson,
+		common.TfmtYaml: efmtYaml,
+	}
+	conflictPolicies = map[string]conflictPolicy{
+		string(conflictFail):     conflictFail,
+		string(conflictSkip):     conflictSkip,
+		string(conflictReplace):  conflictReplace,
+		string(conflictRenumber): conflictRenumber,
+	}
+)
+
+type BlockImportOptions struct {
+	command     *cobra.Command
+	file        string
+	input       string
+	inputFormat exportFormat
+	onConflict  string
+	policy      conflictPolicy
+	force       bool
+	dryRun      common.DryRunOptions
+}
+
+func NewCmdBlockImport() *cobra.Command {
+
+	opt := &BlockImportOptions{
+		file:       stdinFile,
+		input:      formatAuto,
+		onConflict: string(conflictFail),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: fmt.Sprintf("Imports IP aliases blocks into %s file", hosts.EtcHosts.Path()),
+		Long: fmt.Sprintf(`Imports IP aliases blocks exported by 'block export' into %s file.
+
+The blocks are read from the file or from stdin if no file is specified.
+A block conflicts with an existing block if they have the same name or id,
+conflicts are resolved according to --on-conflict:
+  fail      stop with an error (default)
+  skip      keep the existing block, don't import the new one
+  replace   put the new block in place of the existing block with the same name,
+            or with the same id if no block has the name; fails if the new block
+            conflicts with more than one existing block
+  renumber  import the new block with a new id, names still must be unique`, hosts.EtcHosts.Path()),
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.RunCliCommand(opt, cmd, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&opt.input, "format", opt.input, fmt.Sprintf("Input format. One of %s", strings.Join(maps.Keys(importFormats), ",")))
+	cmd.Flags().StringVar(&opt.onConflict, "on-conflict", opt.onConflict, fmt.Sprintf("Conflict resolution policy. One of %s", strings.Join(maps.Keys(conflictPolicies), ",")))
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Replace or merge blocks even if they are locked")
+
+	common.AddDryRunFlags(cmd, &opt.dryRun)
+
+	return cmd
+}
+
+func (opt *BlockImportOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
+func (opt *BlockImportOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	var ok bool
+	opt.inputFormat, ok = importFormats[opt.input]
+	if !ok {
+		return fmt.Errorf("value %v is not support; %w", opt.input, common.ErrNotSupportedOutputFormat)
+	}
+	opt.policy, ok = conflictPolicies[opt.onConflict]
+	if !ok {
+		return fmt.Errorf("conflict policy %v is not supported; %w", opt.onConflict, common.ErrWrongArgumentValue)
+	}
+
+	if len(args) > 0 {
+		opt.file = args[0]
+	}
+
+	return nil
+}
+
+func (opt *BlockImportOptions) Validate() error {
+	args := opt.command.Flags().Args()
+	if len(args) > 1 {
+		return common.ErrTooManyArguments
+	}
+	return nil
+}
+
+func (opt *BlockImportOptions) Execute() error {
+	data, err := opt.read()
+	cobra.CheckErr(err)
+
+	blocks, err := parseImportedBlocks(data, opt.input, opt.inputFormat)
+	cobra.CheckErr(err)
+
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
+	doc, err := src.Load()
+	cobra.CheckErr(err)
+	locked := common.FindLockedBlocks(doc)
+
+	results := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		res, err := importBlock(doc, block, opt.policy)
+		cobra.CheckErr(err)
+		results = append(results, fmt.Sprintf("block %s %s", blockLabel(block), res))
+	}
+
+	err = common.CheckLockedBlocks(doc, locked, opt.force)
+	cobra.CheckErr(err)
+
+	doc.Normalize()
+
+	err = src.Save(doc, dom.FmtKeep)
+	cobra.CheckErr(err)
+
+	for _, r := range results {
+		fmt.Fprintln(opt.command.OutOrStdout(), r)
+	}
+
+	return nil
+}
+
+func (opt *BlockImportOptions) read() ([]byte, error) {
+	if opt.file == stdinFile {
+		return io.ReadAll(opt.command.InOrStdin())
+	}
+
+	fs := common.FileSystem(opt.command.Context())
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+	return afero.ReadFile(fs, opt.file)
+}
+
+// Parses blocks from hosts text or from JSON/YAML block model
+func parseImportedBlocks(data []byte, input string, format exportFormat) ([]*dom.IPAliasesBlock, error) {
+	if input == formatAuto && looksLikeModel(data) {
+		format = efmtYaml
+	}
+
+	switch format {
+	case efmtHosts:
+		return parseHostsBlocks(data)
+	case efmtJson, efmtYaml:
+		m := &BlockExportModel{}
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(m); err != nil {
+			return nil, fmt.Errorf("can't parse block, %w", err)
+		}
+		if err := m.Validate(); err != nil {
+			return nil, err
+		}
+		return []*dom.IPAliasesBlock{m.ToBlock()}, nil
+	default:
+		panic("unknown input format")
+	}
+}
+
+// Returns true if the data starts as JSON object or YAML mapping rather than hosts text
+func looksLikeModel(data []byte) bool {
+	text := strings.TrimSpace(string(data))
+	if strings.HasPrefix(text, "{") {
+		return true
+	}
+	for _, key := range []string{"id:", "name:", "note:", "entries:"} {
+		if strings.HasPrefix(text, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseHostsBlocks(data []byte) ([]*dom.IPAliasesBlock, error) {
+	doc, err := dom.Read(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range doc.Blocks() {
+		if b.Type() == dom.Unknown {
+			elements := b.(*dom.UnrecognizedBlock).BodyElements()
+			return nil, fmt.Errorf("error in input line %d", elements[0].OriginalLineIndex())
+		}
+	}
+	blocks := doc.IPBlocks()
+	if len(blocks) == 0 {
+		return nil, errors.New("no IP aliases blocks found")
+	}
+	return blocks, nil
+}
+
+// Adds the block to the document resolving conflicts with existing blocks according to the policy
+func importBlock(doc *dom.Document, block *dom.IPAliasesBlock, policy conflictPolicy) (string, error) {
+	byName, byId := findConflictingBlocks(doc, block)
+	conflicts := append(byName, byId...)
+	if len(conflicts) == 0 {
+		doc.AddBlock(block)
+		return "imported", nil
+	}
+
+	switch policy {
+	case conflictSkip:
+		return "skipped", nil
+	case conflictReplace:
+		if len(conflicts) > 1 {
+			return "", fmt.Errorf("block %s conflicts with %d existing blocks, only one block can be replaced: %w", blockLabel(block), len(conflicts), common.ErrTooManyEntries)
+		}
+		doc.ReplaceBlock(conflicts[0], block)
+		return "replaced", nil
+	case conflictRenumber:
+		if len(byName) == 0 {
+			block.SetId(nextFreeBlockId(doc))
+			doc.AddBlock(block)
+			return "imported", nil
+		}
+	}
+
+	c := conflicts[0]
+	return "", fmt.Errorf("block %s conflicts with existing block %s: %w", blockLabel(block), blockLabel(c), common.ErrEntryAlreadyExists)
+}
+
+// Returns existing blocks with the same name and, separately, the ones with the same id
+func findConflictingBlocks(doc *dom.Document, block *dom.IPAliasesBlock) ([]*dom.IPAliasesBlock, []*dom.IPAliasesBlock) {
+	byName := make([]*dom.IPAliasesBlock, 0)
+	byId := make([]*dom.IPAliasesBlock, 0)
+	for _, b := range doc.IPBlocks() {
+		if block.Name() != "" && strings.EqualFold(b.Name(), block.Name()) {
+			byName = append(byName, b)
+		} else if block.IdSet() && b.Id() == block.Id() {
+			byId = append(byId, b)
+		}
+	}
+	return byName, byId
+}
+
+func nextFreeBlockId(doc *dom.Document) int {
+	id := 0
+	for _, b := range doc.IPBlocks() {
+		if b.Id() > id {
+			id = b.Id()
+		}
+	}
+	return id + 1
+}
+
+func blockLabel(block *dom.IPAliasesBlock) string {
+	if block.Name() != "" {
+		return block.Name()
+	}
+	if block.IdSet() {
+		return fmt.Sprintf("[%d]", block.Id())
+	}
+	return "[*]"
+}
@@ -0,0 +1,119 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestBlockImportCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "import - one ip",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"/tmp/pet-prj2.txt"},
+				InputFile:  "testdata/one-ip.txt",
+				ExtraFiles: map[string]string{"/tmp/pet-prj2.txt": "testdata/import/pet-prj2.txt"},
+				OutputFile: "testdata/import/import__one_ip__result.txt",
+				Stdout:     "block pet-prj2 imported\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "import - conflict",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"/tmp/pet-prj2.txt"},
+				InputFile:  "testdata/five-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/pet-prj2.txt": "testdata/import/pet-prj2.txt"},
+				ErrorText:  "conflicts with existing block",
+			},
+			Want: false,
+		},
+		{
+			Name: "import skip - conflict",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"/tmp/pet-prj2.txt", "--on-conflict", "skip"},
+				InputFile:  "testdata/five-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/pet-prj2.txt": "testdata/import/pet-prj2.txt"},
+				OutputFile: "testdata/five-blocks.txt",
+				Stdout:     "block pet-prj2 skipped\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "import replace - yaml",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"/tmp/pet-prj2.yaml", "--on-conflict", "replace"},
+				InputFile:  "testdata/five-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/pet-prj2.yaml": "testdata/import/pet-prj2.yaml"},
+				OutputFile: "testdata/import/import_replace__yaml__result.txt",
+				Stdout:     "block pet-prj2 replaced\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "import replace - hosts id",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"/tmp/k8s-local.txt", "--on-conflict", "replace"},
+				InputFile:  "testdata/five-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/k8s-local.txt": "testdata/import/k8s-local.txt"},
+				OutputFile: "testdata/import/import_replace__hosts_id__result.txt",
+				Stdout:     "block k8s-local replaced\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "import replace - name and id conflicts",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"/tmp/pet-prj2.txt", "--on-conflict", "replace"},
+				InputFile:  "testdata/five-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/pet-prj2.txt": "testdata/import/pet-prj2-id-15.txt"},
+				ErrorText:  "block pet-prj2 conflicts with 2 existing blocks, only one block can be replaced",
+			},
+			Want: false,
+		},
+		{
+			Name: "import renumber - hosts",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"/tmp/k8s-local.txt", "--on-conflict", "renumber"},
+				InputFile:  "testdata/five-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/k8s-local.txt": "testdata/import/k8s-local.txt"},
+				OutputFile: "testdata/import/import_renumber__hosts__result.txt",
+				Stdout:     "block k8s-local imported\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "import renumber - name conflict",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"/tmp/pet-prj2.txt", "--on-conflict", "renumber"},
+				InputFile:  "testdata/five-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/pet-prj2.txt": "testdata/import/pet-prj2.txt"},
+				ErrorText:  "conflicts with existing block",
+			},
+			Want: false,
+		},
+		{
+			Name: "import - wrong policy",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"--on-conflict", "merge"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "conflict policy merge is not supported",
+			},
+			Want: false,
+		},
+		{
+			Name: "import - no blocks",
+			Args: cmdtest.ITArgs{
+				Args:      []string{},
+				Stdin:     "",
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "no IP aliases blocks found",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestBlockImportCommand", func() *cobra.Command { return NewCmdBlockImport() })
+}
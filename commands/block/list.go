@@ -37,11 +37,19 @@ var (
 	}
 )
 
+const listFilterHelp = `Filter values are matched against block ids, names and IP addresses, a block is shown if it matches any of them:
+  3                block id
+  pet-*            block names glob pattern
+  /^k8s-/          block names regular expression
+  192.168.1.10     blocks containing the IP address
+  192.168.0.0/16   blocks containing IP addresses from the network`
+
 type BlockListOptions struct {
 	command      *cobra.Command
 	output       string
 	outputFormat outFormat
 	noHeaders    bool
+	filter       BlockFilter
 }
 
 func NewCmdBlockList() *cobra.Command {
@@ -51,6 +59,7 @@ func NewCmdBlockList() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "list [(-o|--output)=name] [filter]",
 		Short:   fmt.Sprintf("Lists IP aliases blocks defined in %s", hosts.EtcHosts.Path()),
+		Long:    fmt.Sprintf("Lists IP aliases blocks defined in %s\n\n%s", hosts.EtcHosts.Path(), listFilterHelp),
 		Aliases: []string{"ls"},
 		Run: func(cmd *cobra.Command, args []string) {
 			cobra.CheckErr(opt.Complete(cmd, args))
@@ -61,6 +70,10 @@ func NewCmdBlockList() *cobra.Command {
 
 	cmd.Flags().BoolVar(&opt.noHeaders, "no-headers", opt.noHeaders, "Disable printing headers")
 	cmd.Flags().StringVarP(&opt.output, "output", "o", opt.output, fmt.Sprintf("Output format. One of %s", strings.Join(maps.Keys(formats), ",")))
+	cmd.Flags().BoolVar(&opt.filter.systemOnly, "system", opt.filter.systemOnly, "Show only blocks containing system aliases")
+	cmd.Flags().BoolVar(&opt.filter.noSystem, "no-system", opt.filter.noSystem, "Hide blocks containing system aliases")
+	cmd.Flags().BoolVar(&opt.filter.disabledOnly, "disabled", opt.filter.disabledOnly, "Show only blocks containing disabled aliases")
+	cmd.Flags().StringVar(&opt.filter.comment, "comment", opt.filter.comment, "Show only blocks which comment contains the specified text")
 
 	return cmd
 }
@@ -75,15 +88,25 @@ func (opt *BlockListOptions) Complete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("value %v is not support; %w", opt.output, common.ErrNotSupportedOutputFormat)
 	}
 
+	var err error
+	opt.filter.terms, err = common.ParseFilterTerms(args)
+	if err != nil {
+		return err
+	}
+	opt.filter.system = common.SystemAliases(cmd.Context())
+
 	return nil
 }
 
 func (opt *BlockListOptions) Validate() error {
+	if opt.filter.systemOnly && opt.filter.noSystem {
+		return fmt.Errorf("flags --system and --no-system can't be used together; %w", common.ErrWrongArgumentValue)
+	}
 	return nil
 }
 
 func (opt *BlockListOptions) Execute() error {
-	src := hosts.NewSource(hosts.EtcHosts.Path(), common.FileSystem(opt.command.Context()))
+	src := common.HostsSource(opt.command.Context())
 	c, err := src.Load()
 	cobra.CheckErr(err)
 
@@ -103,12 +126,12 @@ func (opt *BlockListOptions) Execute() error {
 }
 
 func writeDataAsText(opt *BlockListOptions, data *dom.Document) error {
-	m := NewBlocksModels(data)
+	m := NewBlocksModels(data, &opt.filter, opt.filter.system)
 
 	err := iotools.PrintTabbed(opt.command.OutOrStdout(), nil, 2, func(w io.Writer) error {
 
 		if !opt.noHeaders {
-			columns := []string{"ID", "SYS", "NAME", "COMMENT", "ALIASES", "SYSTEM ALIASES"}
+			columns := []string{"ID", "SYS", "NAME", "COMMENT", "ALIASES", "SYSTEM ALIASES", "LABELS"}
 			visible := getVisibleValues(opt, columns)
 			fmt.Fprint(w, strings.Join(visible, "\t"))
 			fmt.Fprintln(w)
@@ -123,7 +146,12 @@ func writeDataAsText(opt *BlockListOptions, data *dom.Document) error {
 				sys = "*"
 			}
 
-			values := []string{strconv.Itoa(b.ID), sys, b.Name, b.Comment, strconv.Itoa(b.AliasesCount), strconv.Itoa(b.SystemAliasesCount)}
+			labels := make([]string, 0, len(b.labelKeys))
+			for _, k := range b.labelKeys {
+				labels = append(labels, fmt.Sprintf("%s=%s", k, b.Labels[k]))
+			}
+
+			values := []string{strconv.Itoa(b.ID), sys, b.Name, b.Comment, strconv.Itoa(b.AliasesCount), strconv.Itoa(b.SystemAliasesCount), strings.Join(labels, ",")}
 
 			visible := getVisibleValues(opt, values)
 			fmt.Fprint(w, strings.Join(visible, "\t"))
@@ -135,7 +163,7 @@ func writeDataAsText(opt *BlockListOptions, data *dom.Document) error {
 }
 
 func writeDataAsJson(opt *BlockListOptions, data *dom.Document) error {
-	m := NewBlocksModels(data)
+	m := NewBlocksModels(data, &opt.filter, opt.filter.system)
 	buff, err := json.Marshal(m)
 	if err != nil {
 		return err
@@ -145,7 +173,7 @@ func writeDataAsJson(opt *BlockListOptions, data *dom.Document) error {
 }
 
 func writeDataAsYaml(opt *BlockListOptions, data *dom.Document) error {
-	m := NewBlocksModels(data)
+	m := NewBlocksModels(data, &opt.filter, opt.filter.system)
 	buff, err := yaml.Marshal(m)
 	if err != nil {
 		return err
@@ -155,7 +183,7 @@ func writeDataAsYaml(opt *BlockListOptions, data *dom.Document) error {
 }
 
 func getVisibleValues(opt *BlockListOptions, values []string) []string {
-	// "ID", "SYS", "NAME", "COMMENT", "ALIASES", "SYSTEM ALIASES"
+	// "ID", "SYS", "NAME", "COMMENT", "ALIASES", "SYSTEM ALIASES", "LABELS"
 	switch opt.outputFormat {
 	case fmtText:
 		return values[:3]
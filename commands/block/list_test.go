@@ -43,6 +43,88 @@ func TestBlockListCommand(t *testing.T) {
 			},
 			Want: true,
 		},
+		// filter
+		{
+			Name: "filter - glob and id",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"pet*", "1"},
+				InputFile:  "testdata/six-blocks.txt",
+				StdoutFile: "testdata/list/filter_glob__six_blocks__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter wide - network",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"192.168.0.0/16", "-o", "wide"},
+				InputFile:  "testdata/six-blocks.txt",
+				StdoutFile: "testdata/list/filter_ip_wide__six_blocks__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter json - system",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--system", "-o", "json"},
+				InputFile:  "testdata/six-blocks.txt",
+				StdoutFile: "testdata/list/filter_system_json__six_blocks__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter yaml - regex and comment",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"/prj3$/", "--comment", "old", "-o", "yaml"},
+				InputFile:  "testdata/six-blocks.txt",
+				StdoutFile: "testdata/list/filter_regex_yaml__six_blocks__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter short - disabled",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"--disabled", "--no-system", "-o", "short", "--no-headers"},
+				InputFile: "testdata/disabled-entries.txt",
+				Stdout:    "2  pet-prj1\n3  pet-prj2\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "filter error - system and no-system",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"--system", "--no-system"},
+				InputFile: "testdata/six-blocks.txt",
+				ErrorText: "flags --system and --no-system can't be used together; wrong argument value",
+			},
+			Want: false,
+		},
+		{
+			Name: "list wide - labels",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-o", "wide"},
+				InputFile:  "testdata/labeled-blocks.txt",
+				StdoutFile: "testdata/list/list_wide__labeled_blocks__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "list json - labels",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-o", "json"},
+				InputFile:  "testdata/labeled-blocks.txt",
+				StdoutFile: "testdata/list/list_json__labeled_blocks__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "list yaml - labels",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-o", "yaml"},
+				InputFile:  "testdata/labeled-blocks.txt",
+				StdoutFile: "testdata/list/list_yaml__labeled_blocks__output.txt",
+			},
+			Want: true,
+		},
 	}
 	cmdtest.RunIntergationTests(t, tests, "TestBlockListCommand", func() *cobra.Command { return NewCmdBlockList() })
 }
@@ -0,0 +1,128 @@
+package block
+
+import (
+	"fmt"
+	"os/user"
+	"strings"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/spf13/cobra"
+)
+
+type BlockLockOptions struct {
+	command *cobra.Command
+	block   string
+	by      string
+	unlock  bool
+	force   bool
+	dryRun  common.DryRunOptions
+}
+
+func NewCmdBlockLock() *cobra.Command {
+	return newCmdBlockLock(false)
+}
+
+func NewCmdBlockUnlock() *cobra.Command {
+	return newCmdBlockLock(true)
+}
+
+func newCmdBlockLock(unlock bool) *cobra.Command {
+
+	opt := &BlockLockOptions{
+		unlock: unlock,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "lock <id or name>",
+		Short: fmt.Sprintf("Locks IP aliases block in %s file", hosts.EtcHosts.Path()),
+		Long: fmt.Sprintf(`Locks IP aliases block in %s file.
+
+The block header is marked as locked, e.g. "# [3] corp-vpn - locked by ansible - Corporate VPN",
+commands changing the hosts file refuse to change locked blocks unless --force is specified.`, hosts.EtcHosts.Path()),
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.RunCliCommand(opt, cmd, args)
+		},
+	}
+	if unlock {
+		cmd.Use = "unlock <id or name>"
+		cmd.Short = fmt.Sprintf("Removes lock marker of IP aliases block in %s file", hosts.EtcHosts.Path())
+		cmd.Long = ""
+	} else {
+		cmd.Flags().StringVar(&opt.by, "by", opt.by, "Who or what locks the block, defaults to the current user")
+		cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Lock the block even if it is already locked by someone else")
+	}
+
+	common.AddDryRunFlags(cmd, &opt.dryRun)
+
+	return cmd
+}
+
+func (opt *BlockLockOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
+func (opt *BlockLockOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	parsedArgs := cmd.Flags().Args()
+	if len(parsedArgs) > 0 {
+		opt.block = parsedArgs[0]
+	}
+	if !opt.unlock && !cmd.Flags().Changed("by") {
+		if u, err := user.Current(); err == nil {
+			opt.by = u.Username
+		}
+	}
+
+	return nil
+}
+
+func (opt *BlockLockOptions) Validate() error {
+	args := opt.command.Flags().Args()
+	if len(args) > 1 {
+		return common.ErrTooManyArguments
+	}
+	if len(args) < 1 {
+		return fmt.Errorf("block id or name expected; %w", common.ErrNotEnoughArguments)
+	}
+	if strings.ContainsAny(opt.by, " \t:|") {
+		return fmt.Errorf("--by %q must be a single word without ':' and '|'; %w", opt.by, common.ErrWrongArgumentValue)
+	}
+	return nil
+}
+
+func (opt *BlockLockOptions) Execute() error {
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
+	doc, err := src.Load()
+	cobra.CheckErr(err)
+
+	block, err := findBlockByIdOrName(doc, opt.block)
+	cobra.CheckErr(err)
+
+	if opt.unlock {
+		if block.Locked() {
+			block.SetLocked(false, "")
+		}
+	} else {
+		if block.Locked() && block.LockedBy() != opt.by && !opt.force {
+			return common.LockedBlockError(common.BlockTitle(block), block.LockedBy())
+		}
+		if !block.Locked() || block.LockedBy() != opt.by {
+			block.SetLocked(true, opt.by)
+		}
+	}
+
+	doc.Normalize()
+
+	err = src.Save(doc, dom.FmtKeep)
+	cobra.CheckErr(err)
+
+	return nil
+}
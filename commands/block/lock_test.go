@@ -0,0 +1,94 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestBlockLockCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "lock - by id",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"18", "--by", "ansible"},
+				InputFile:  "testdata/five-blocks.txt",
+				OutputFile: "testdata/lock/lock__by_id__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "lock - no id block",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"pet-prj2", "--by", "root"},
+				InputFile:  "testdata/five-blocks.txt",
+				OutputFile: "testdata/lock/lock__no_id_block__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "lock - locked by someone else, forced",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"15", "--by", "root", "--force"},
+				InputFile:  "testdata/locked-blocks.txt",
+				OutputFile: "testdata/lock/lock_forced__locked_blocks__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "lock error - locked by someone else",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"15", "--by", "root"},
+				InputFile: "testdata/locked-blocks.txt",
+				ErrorText: "block [15] pet-prj1 is locked by ansible",
+			},
+			Want: false,
+		},
+		{
+			Name: "lock error - by many words",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"15", "--by", "john doe"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "must be a single word",
+			},
+			Want: false,
+		},
+		{
+			Name: "lock error - no block",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"--by", "root"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "block id or name expected",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestBlockLockCommand", func() *cobra.Command { return NewCmdBlockLock() })
+}
+
+func TestBlockUnlockCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "unlock - by name",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"pet-prj1"},
+				InputFile:  "testdata/locked-blocks.txt",
+				OutputFile: "testdata/lock/unlock__by_name__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "unlock - not locked",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"pet-prj2"},
+				InputFile:  "testdata/locked-blocks.txt",
+				OutputFile: "testdata/locked-blocks.txt",
+			},
+			Want: true,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestBlockUnlockCommand", func() *cobra.Command { return NewCmdBlockUnlock() })
+}
@@ -0,0 +1,26 @@
+package block
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+)
+
+// Finds the only block identified by the id or name
+func findBlockByIdOrName(doc *dom.Document, idOrName string) (*dom.IPAliasesBlock, error) {
+	id, name := emptyId, idOrName
+	if v, err := strconv.Atoi(idOrName); err == nil {
+		id, name = v, ""
+	}
+
+	selectedBlocks := doc.IPBlocksByIdentifiers(id, name)
+	blocksFound := len(selectedBlocks)
+	if blocksFound == 0 {
+		return nil, fmt.Errorf("block %s: %w", idOrName, common.ErrBlockNotFound)
+	} else if blocksFound > 1 {
+		return nil, fmt.Errorf("%d blocks found matching %s: %w", blocksFound, idOrName, common.ErrTooManyEntries)
+	}
+	return selectedBlocks[0], nil
+}
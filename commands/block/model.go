@@ -1,36 +1,50 @@
 package block
 
 import (
+	"fmt"
+
+	"github.com/0xcfff/hostsctl/commands/common"
 	"github.com/0xcfff/hostsctl/hosts/dom"
 	"github.com/0xcfff/hostsctl/iptools"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 type BlockModel struct {
-	ID                 int    `json:"id"                yaml:"id"`
-	Name               string `json:"name"              yaml:"name"`
-	Comment            string `json:"comment,omitempty" yaml:"comment,omitempty"`
-	AliasesCount       int    `json:"count,omitempty"   yaml:"count,omitempty"`
-	SystemAliasesCount int    `json:"-"   				yaml:"-"`
+	ID                 int               `json:"id"                yaml:"id"`
+	Name               string            `json:"name"              yaml:"name"`
+	Comment            string            `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Labels             map[string]string `json:"labels,omitempty"  yaml:"labels,omitempty"`
+	AliasesCount       int               `json:"count,omitempty"   yaml:"count,omitempty"`
+	SystemAliasesCount int               `json:"-"                 yaml:"-"`
+	labelKeys          []string
 }
 
-func NewBlocksModels(doc *dom.Document) []*BlockModel {
+// Converts document IP blocks into blocks models, only blocks matching the filter are included
+func NewBlocksModels(doc *dom.Document, filter *BlockFilter, system *iptools.SystemAliasCatalog) []*BlockModel {
 	var result []*BlockModel = make([]*BlockModel, 0)
 
 	for _, block := range doc.Blocks() {
 		if block.Type() == dom.IPList {
 			ipsBlock := block.(*dom.IPAliasesBlock)
-			result = append(result, convertIPs(ipsBlock))
+			if filter.Match(ipsBlock) {
+				result = append(result, convertIPs(ipsBlock, system))
+			}
 		}
 	}
 	return result
 }
 
-func convertIPs(ips *dom.IPAliasesBlock) *BlockModel {
+func convertIPs(ips *dom.IPAliasesBlock, system *iptools.SystemAliasCatalog) *BlockModel {
 	block := &BlockModel{
 		ID:      ips.Id(),
 		Name:    ips.Name(),
 		Comment: ips.Note(),
 	}
+	if keys := ips.LabelKeys(); len(keys) > 0 {
+		block.Labels = ips.Labels()
+		block.labelKeys = keys
+	}
 
 	cntAll := 0
 	cntSys := 0
@@ -38,7 +52,7 @@ func convertIPs(ips *dom.IPAliasesBlock) *BlockModel {
 	for _, ip := range ips.AliasEntries() {
 		for _, a := range ip.Aliases() {
 			cntAll += 1
-			if iptools.IsSystemAlias(ip.IP(), a) {
+			if system.IsSystemAlias(ip.IP(), a) {
 				cntSys += 1
 			}
 		}
@@ -49,3 +63,79 @@ func convertIPs(ips *dom.IPAliasesBlock) *BlockModel {
 
 	return block
 }
+
+// Block with all its entries, the model is used by export and import
+type BlockExportModel struct {
+	Id      *int                `json:"id,omitempty"     yaml:"id,omitempty"`
+	Name    string              `json:"name,omitempty"   yaml:"name,omitempty"`
+	Note    string              `json:"note,omitempty"   yaml:"note,omitempty"`
+	Labels  map[string]string   `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Entries []*EntryExportModel `json:"entries"          yaml:"entries"`
+}
+
+type EntryExportModel struct {
+	IP       string   `json:"ip"                 yaml:"ip"`
+	Aliases  []string `json:"aliases"            yaml:"aliases"`
+	Comment  string   `json:"comment,omitempty"  yaml:"comment,omitempty"`
+	Disabled bool     `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+}
+
+func NewBlockExportModel(block *dom.IPAliasesBlock) *BlockExportModel {
+	m := &BlockExportModel{
+		Name:    block.Name(),
+		Note:    block.Note(),
+		Entries: make([]*EntryExportModel, 0),
+	}
+	if block.IdSet() {
+		id := block.Id()
+		m.Id = &id
+	}
+	if len(block.LabelKeys()) > 0 {
+		m.Labels = block.Labels()
+	}
+	for _, ent := range block.AliasEntries() {
+		m.Entries = append(m.Entries, &EntryExportModel{
+			IP:       ent.IP(),
+			Aliases:  ent.Aliases(),
+			Comment:  ent.Note(),
+			Disabled: ent.Disabled(),
+		})
+	}
+	return m
+}
+
+// Checks the block model has valid IPs and aliases
+func (m *BlockExportModel) Validate() error {
+	for _, e := range m.Entries {
+		if !iptools.IsIP(e.IP) {
+			return fmt.Errorf("invalid IP %s; %w", e.IP, common.ErrWrongArgumentValue)
+		}
+		if len(e.Aliases) == 0 {
+			return fmt.Errorf("no aliases for IP %s; %w", e.IP, common.ErrWrongArgumentValue)
+		}
+	}
+	return nil
+}
+
+// Creates new block from the model
+func (m *BlockExportModel) ToBlock() *dom.IPAliasesBlock {
+	block := dom.NewIPAliasesBlock()
+	if m.Id != nil {
+		block.SetId(*m.Id)
+	}
+	block.SetName(m.Name)
+	block.SetNote(m.Note)
+	keys := maps.Keys(m.Labels)
+	slices.Sort(keys)
+	for _, k := range keys {
+		block.SetLabel(k, m.Labels[k])
+	}
+	for _, em := range m.Entries {
+		ent := dom.NewIPAliasesEntry(em.IP)
+		ent.SetAliases(em.Aliases)
+		ent.SetNote(em.Comment)
+		ent.SetDisabled(em.Disabled)
+		block.AddEntry(ent)
+	}
+	return block
+}
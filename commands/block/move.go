@@ -0,0 +1,132 @@
+package block
+
+import (
+	"fmt"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/spf13/cobra"
+)
+
+type BlockMoveOptions struct {
+	command  *cobra.Command
+	block    string
+	before   string
+	after    string
+	toTop    bool
+	toBottom bool
+	dryRun   common.DryRunOptions
+}
+
+func NewCmdBlockMove() *cobra.Command {
+
+	opt := &BlockMoveOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "move <id or name> (--before=block|--after=block|--to-top|--to-bottom)",
+		Short: fmt.Sprintf("Changes position of IP aliases block in %s file", hosts.EtcHosts.Path()),
+		Long: fmt.Sprintf(`Changes position of IP aliases block in %s file.
+
+Aliases are resolved using the first matching line, so the order of blocks defines
+which IP wins if an alias is defined in several blocks. The block is moved together
+with blank lines separating it from the next block.`, hosts.EtcHosts.Path()),
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.RunCliCommand(opt, cmd, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&opt.before, "before", opt.before, "Id or name of the block to put the block before")
+	cmd.Flags().StringVar(&opt.after, "after", opt.after, "Id or name of the block to put the block after")
+	cmd.Flags().BoolVar(&opt.toTop, "to-top", opt.toTop, "Put the block at the beginning of the file")
+	cmd.Flags().BoolVar(&opt.toBottom, "to-bottom", opt.toBottom, "Put the block at the end of the file")
+
+	common.AddDryRunFlags(cmd, &opt.dryRun)
+
+	return cmd
+}
+
+func (opt *BlockMoveOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
+func (opt *BlockMoveOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	parsedArgs := cmd.Flags().Args()
+	if len(parsedArgs) > 0 {
+		opt.block = parsedArgs[0]
+	}
+
+	return nil
+}
+
+func (opt *BlockMoveOptions) Validate() error {
+	args := opt.command.Flags().Args()
+	if len(args) > 1 {
+		return common.ErrTooManyArguments
+	}
+	if len(args) < 1 {
+		return fmt.Errorf("block id or name expected; %w", common.ErrNotEnoughArguments)
+	}
+
+	positions := 0
+	for _, set := range []bool{opt.before != "", opt.after != "", opt.toTop, opt.toBottom} {
+		if set {
+			positions++
+		}
+	}
+	if positions != 1 {
+		return fmt.Errorf("exactly one of --before, --after, --to-top or --to-bottom expected; %w", common.ErrWrongArgumentValue)
+	}
+	return nil
+}
+
+func (opt *BlockMoveOptions) Execute() error {
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
+	doc, err := src.Load()
+	cobra.CheckErr(err)
+
+	block, err := findBlockByIdOrName(doc, opt.block)
+	cobra.CheckErr(err)
+
+	err = moveBlock(doc, block, opt)
+	cobra.CheckErr(err)
+
+	err = src.Save(doc, dom.FmtKeep)
+	cobra.CheckErr(err)
+
+	return nil
+}
+
+func moveBlock(doc *dom.Document, block *dom.IPAliasesBlock, opt *BlockMoveOptions) error {
+	switch {
+	case opt.toTop:
+		doc.MoveBlockToTop(block)
+	case opt.toBottom:
+		doc.MoveBlockToBottom(block)
+	default:
+		targetIdOrName, before := opt.after, false
+		if opt.before != "" {
+			targetIdOrName, before = opt.before, true
+		}
+		target, err := findBlockByIdOrName(doc, targetIdOrName)
+		if err != nil {
+			return err
+		}
+		if target == block {
+			return fmt.Errorf("block can't be moved relative to itself; %w", common.ErrWrongArgumentValue)
+		}
+		if before {
+			doc.MoveBlockBefore(block, target)
+		} else {
+			doc.MoveBlockAfter(block, target)
+		}
+	}
+	return nil
+}
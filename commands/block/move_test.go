@@ -0,0 +1,87 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestBlockMoveCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "move to top - by name",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"pet-prj2", "--to-top"},
+				InputFile:  "testdata/five-blocks.txt",
+				OutputFile: "testdata/move/move_to_top__by_name__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "move to bottom - by id",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"15", "--to-bottom"},
+				InputFile:  "testdata/five-blocks.txt",
+				OutputFile: "testdata/move/move_to_bottom__by_id__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "move before - by name",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"pet-prj2", "--before", "15"},
+				InputFile:  "testdata/five-blocks.txt",
+				OutputFile: "testdata/move/move_before__by_name__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "move after - by id",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"15", "--after", "pet-prj3"},
+				InputFile:  "testdata/five-blocks.txt",
+				OutputFile: "testdata/move/move_after__by_id__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "move - no position",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"15"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "exactly one of",
+			},
+			Want: false,
+		},
+		{
+			Name: "move - many positions",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"15", "--to-top", "--to-bottom"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "exactly one of",
+			},
+			Want: false,
+		},
+		{
+			Name: "move - relative to itself",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"18", "--after", "pet-prj3"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "relative to itself",
+			},
+			Want: false,
+		},
+		{
+			Name: "move - target not found",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"18", "--before", "99"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "block not found",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestBlockMoveCommand", func() *cobra.Command { return NewCmdBlockMove() })
+}
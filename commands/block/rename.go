@@ -0,0 +1,121 @@
+package block
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/spf13/cobra"
+)
+
+type BlockRenameOptions struct {
+	command *cobra.Command
+	block   string
+	newName string
+	force   bool
+	dryRun  common.DryRunOptions
+}
+
+func NewCmdBlockRename() *cobra.Command {
+
+	opt := &BlockRenameOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "rename <id or name> <new name>",
+		Short: fmt.Sprintf("Renames IP aliases block in %s file", hosts.EtcHosts.Path()),
+		Long: fmt.Sprintf(`Renames IP aliases block in %s file.
+
+The new name must be a single word which is not a number,
+block names are unique regardless of the letter case.`, hosts.EtcHosts.Path()),
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.RunCliCommand(opt, cmd, args)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Rename the block even if it is locked")
+	common.AddDryRunFlags(cmd, &opt.dryRun)
+
+	return cmd
+}
+
+func (opt *BlockRenameOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
+func (opt *BlockRenameOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	parsedArgs := cmd.Flags().Args()
+	if len(parsedArgs) > 0 {
+		opt.block = parsedArgs[0]
+	}
+	if len(parsedArgs) > 1 {
+		opt.newName = parsedArgs[1]
+	}
+
+	return nil
+}
+
+func (opt *BlockRenameOptions) Validate() error {
+	args := opt.command.Flags().Args()
+	if len(args) > 2 {
+		return common.ErrTooManyArguments
+	}
+	if len(args) < 2 {
+		return fmt.Errorf("block and new name expected; %w", common.ErrNotEnoughArguments)
+	}
+	return validateBlockName(opt.newName)
+}
+
+func (opt *BlockRenameOptions) Execute() error {
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
+	doc, err := src.Load()
+	cobra.CheckErr(err)
+	locked := common.FindLockedBlocks(doc)
+
+	block, err := findBlockByIdOrName(doc, opt.block)
+	cobra.CheckErr(err)
+
+	for _, b := range doc.IPBlocks() {
+		if b != block && strings.EqualFold(b.Name(), opt.newName) {
+			return fmt.Errorf("block named %s already exists; %w", b.Name(), common.ErrEntryAlreadyExists)
+		}
+	}
+
+	if block.Name() != opt.newName {
+		block.SetName(opt.newName)
+	}
+
+	err = common.CheckLockedBlocks(doc, locked, opt.force)
+	cobra.CheckErr(err)
+
+	doc.Normalize()
+
+	err = src.Save(doc, dom.FmtKeep)
+	cobra.CheckErr(err)
+
+	return nil
+}
+
+// Checks the name can be written to the block header and read back
+func validateBlockName(name string) error {
+	fields := strings.Fields(name)
+	if len(fields) != 1 || fields[0] != name {
+		return fmt.Errorf("block name %q must be a single word; %w", name, common.ErrWrongArgumentValue)
+	}
+	if _, err := strconv.Atoi(name); err == nil {
+		return fmt.Errorf("block name %q can't be a number; %w", name, common.ErrWrongArgumentValue)
+	}
+	if strings.HasPrefix(name, "[") {
+		return fmt.Errorf("block name %q can't start with '['; %w", name, common.ErrWrongArgumentValue)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestBlockRenameCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "rename - by id",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"15", "pet-cats"},
+				InputFile:  "testdata/five-blocks.txt",
+				OutputFile: "testdata/rename/rename__by_id__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "rename - no id block",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"pet-prj2", "pet-users"},
+				InputFile:  "testdata/five-blocks.txt",
+				OutputFile: "testdata/rename/rename__no_id_block__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "rename - name exists",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"15", "PET-PRJ2"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "already exists",
+			},
+			Want: false,
+		},
+		{
+			Name: "rename - numeric name",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"15", "42"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "can't be a number",
+			},
+			Want: false,
+		},
+		{
+			Name: "rename - many words",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"15", "pet cats"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "must be a single word",
+			},
+			Want: false,
+		},
+		{
+			Name: "rename - block not found",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"99", "pet-cats"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "block not found",
+			},
+			Want: false,
+		},
+		{
+			Name: "rename - no new name",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"15"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "not enough arguments",
+			},
+			Want: false,
+		},
+		{
+			Name: "rename locked - forced",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"corp-vpn", "corp-net", "--force"},
+				InputFile:  "testdata/locked-blocks.txt",
+				OutputFile: "testdata/rename/rename_locked_forced__by_name__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "rename error - locked",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"corp-vpn", "corp-net"},
+				InputFile: "testdata/locked-blocks.txt",
+				ErrorText: "block is locked",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestBlockRenameCommand", func() *cobra.Command { return NewCmdBlockRename() })
+}
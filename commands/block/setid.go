@@ -0,0 +1,110 @@
+package block
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/spf13/cobra"
+)
+
+type BlockSetIdOptions struct {
+	command *cobra.Command
+	block   string
+	newId   int
+	force   bool
+	dryRun  common.DryRunOptions
+}
+
+func NewCmdBlockSetId() *cobra.Command {
+
+	opt := &BlockSetIdOptions{}
+	opt.newId = emptyId
+
+	cmd := &cobra.Command{
+		Use:   "set-id <id or name> <new id>",
+		Short: fmt.Sprintf("Changes id of IP aliases block in %s file", hosts.EtcHosts.Path()),
+		Long: fmt.Sprintf(`Changes id of IP aliases block in %s file.
+
+The new id must be a non negative number not used by other blocks.`, hosts.EtcHosts.Path()),
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.RunCliCommand(opt, cmd, args)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Change id of the block even if it is locked")
+	common.AddDryRunFlags(cmd, &opt.dryRun)
+
+	return cmd
+}
+
+func (opt *BlockSetIdOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
+func (opt *BlockSetIdOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	parsedArgs := cmd.Flags().Args()
+	if len(parsedArgs) > 0 {
+		opt.block = parsedArgs[0]
+	}
+	if len(parsedArgs) > 1 {
+		id, err := strconv.Atoi(parsedArgs[1])
+		if err != nil || id < 0 {
+			return fmt.Errorf("block id %s is not a non negative number; %w", parsedArgs[1], common.ErrWrongArgumentValue)
+		}
+		opt.newId = id
+	}
+
+	return nil
+}
+
+func (opt *BlockSetIdOptions) Validate() error {
+	args := opt.command.Flags().Args()
+	if len(args) > 2 {
+		return common.ErrTooManyArguments
+	}
+	if len(args) < 2 {
+		return fmt.Errorf("block and new id expected; %w", common.ErrNotEnoughArguments)
+	}
+	return nil
+}
+
+func (opt *BlockSetIdOptions) Execute() error {
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
+	doc, err := src.Load()
+	cobra.CheckErr(err)
+	locked := common.FindLockedBlocks(doc)
+
+	block, err := findBlockByIdOrName(doc, opt.block)
+	cobra.CheckErr(err)
+
+	// auto assigned ids count too, otherwise the other block is renumbered when the file is read again
+	for _, b := range doc.IPBlocks() {
+		if b != block && b.Id() == opt.newId {
+			return fmt.Errorf("block with id %d already exists; %w", opt.newId, common.ErrEntryAlreadyExists)
+		}
+	}
+
+	if !block.IdSet() || block.Id() != opt.newId {
+		block.SetId(opt.newId)
+	}
+
+	err = common.CheckLockedBlocks(doc, locked, opt.force)
+	cobra.CheckErr(err)
+
+	doc.Normalize()
+
+	err = src.Save(doc, dom.FmtKeep)
+	cobra.CheckErr(err)
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestBlockSetIdCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "set id - by name",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"pet-prj2", "20"},
+				InputFile:  "testdata/five-blocks.txt",
+				OutputFile: "testdata/set-id/set_id__by_name__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "set id - by id",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"18", "25"},
+				InputFile:  "testdata/five-blocks.txt",
+				OutputFile: "testdata/set-id/set_id__by_id__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "set id - id exists",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"15", "18"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "already exists",
+			},
+			Want: false,
+		},
+		{
+			Name: "set id - id auto assigned to another block",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"15", "3"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "block with id 3 already exists",
+			},
+			Want: false,
+		},
+		{
+			Name: "set id - not a number",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"15", "abc"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "is not a non negative number",
+			},
+			Want: false,
+		},
+		{
+			Name: "set id - block not found",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"pet-prj9", "20"},
+				InputFile: "testdata/five-blocks.txt",
+				ErrorText: "block not found",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestBlockSetIdCommand", func() *cobra.Command { return NewCmdBlockSetId() })
+}
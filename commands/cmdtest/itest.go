@@ -4,13 +4,16 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/0xcfff/hostsctl/commands/common"
 	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/iptools"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -20,11 +23,16 @@ import (
 type ITArgs struct {
 	Args       []string
 	Stdin      string
+	HostsFile  string
 	InputFile  string
+	ExtraFiles map[string]string // test filesystem path to the source file map
 	OutputFile string
 	Stdout     string
 	StdoutFile string
 	ErrorText  string
+	ExitCode   int       // exit code of failing command, any non-zero code is accepted if not set
+	Hostname   string    // machine hostname, system aliases of linux and the hostname are used by commands
+	Now        time.Time // current time seen by commands, real time if not set
 }
 
 // Command test case
@@ -50,13 +58,21 @@ func RunIntergationTests(t *testing.T, tcs []ITTest, tn string, cf func() *cobra
 				out, _ := tstp.CombinedOutput()
 				fmt.Println(string(out))
 				assert.NotEqual(t, 0, tstp.ProcessState.ExitCode())
+				if tt.Args.ExitCode != 0 {
+					assert.Equal(t, tt.Args.ExitCode, tstp.ProcessState.ExitCode())
+				}
 				assert.Contains(t, string(out), tt.Args.ErrorText)
+				// output of the command is mixed with the errors and test framework output
+				assert.Contains(t, string(out), readExpectedStdout(t, tt.Args))
 				return
 			}
 
 			// arrange
 			fs := afero.NewMemMapFs()
 			fn := hosts.EtcHosts.Path()
+			if tt.Args.HostsFile != "" {
+				fn = tt.Args.HostsFile
+			}
 			f, err := fs.Create(fn)
 			if err != nil {
 				t.Errorf("Can't create %v", fn)
@@ -71,6 +87,15 @@ func RunIntergationTests(t *testing.T, tcs []ITTest, tn string, cf func() *cobra
 			f.WriteString(sdata)
 			f.Close()
 
+			for target, source := range tt.Args.ExtraFiles {
+				data, err := os.ReadFile(source)
+				if err != nil {
+					t.Errorf("Can't read %v", source)
+					t.FailNow()
+				}
+				afero.WriteFile(fs, target, data, 0o644)
+			}
+
 			expectDataSpecified := false
 			expectData := bytes.NewBufferString("").Bytes()
 			if tt.Args.OutputFile != "" {
@@ -82,17 +107,16 @@ func RunIntergationTests(t *testing.T, tcs []ITTest, tn string, cf func() *cobra
 				expectDataSpecified = true
 			}
 			expectRes := string(expectData)
-			expectOut := tt.Args.Stdout
-			if tt.Args.StdoutFile != "" {
-				expectOutBytes, err := os.ReadFile(tt.Args.StdoutFile)
-				if err != nil {
-					t.Errorf("Can't read %v", tt.Args.OutputFile)
-					t.FailNow()
-				}
-				expectOut = string(expectOutBytes)
-			}
+			expectOut := readExpectedStdout(t, tt.Args)
 
 			ctx := common.WithCustomFilesystem(context.Background(), fs)
+			ctx = common.WithSystemAliases(ctx, iptools.NewSystemAliasCatalog("linux", tt.Args.Hostname))
+			if tt.Args.HostsFile != "" {
+				ctx = common.WithCustomHostsFile(ctx, tt.Args.HostsFile)
+			}
+			if !tt.Args.Now.IsZero() {
+				ctx = common.WithClock(ctx, func() time.Time { return tt.Args.Now })
+			}
 			in := strings.NewReader(tt.Args.Stdin)
 			out := &strings.Builder{}
 
@@ -100,7 +124,12 @@ func RunIntergationTests(t *testing.T, tcs []ITTest, tn string, cf func() *cobra
 			cmd.SilenceErrors = true
 			cmd.SetArgs(tt.Args.Args)
 			cmd.SetIn(in)
-			cmd.SetOutput(out)
+			if inHelperProcess {
+				// the command may exit the process, its output is checked by the parent process
+				cmd.SetOutput(io.MultiWriter(out, os.Stdout))
+			} else {
+				cmd.SetOutput(out)
+			}
 			cmd.SetContext(ctx)
 
 			// act
@@ -126,6 +155,18 @@ func RunIntergationTests(t *testing.T, tcs []ITTest, tn string, cf func() *cobra
 	}
 }
 
+func readExpectedStdout(t *testing.T, args ITArgs) string {
+	if args.StdoutFile == "" {
+		return args.Stdout
+	}
+	data, err := os.ReadFile(args.StdoutFile)
+	if err != nil {
+		t.Errorf("Can't read %v", args.StdoutFile)
+		t.FailNow()
+	}
+	return string(data)
+}
+
 // Runs binaries of the current process as sub-prpocess passing extra parameters
 // indicating that it is the sub process.
 // This functionality is necessary for being able to test functions
@@ -0,0 +1,93 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"golang.org/x/exp/slices"
+)
+
+// Returns the block with the id or name, the last IP block if no id or name specified and
+// nothing but blank lines follow it, a new block is added otherwise (named one only if createNamedIfMissing)
+func FindOrCreateTargetAliasesBlock(doc *dom.Document, ipBlockIdOrName string, createNamedIfMissing bool) (*dom.IPAliasesBlock, error) {
+
+	// #1 try to find ips block by id
+	var ipsBlock *dom.IPAliasesBlock
+	if ipBlockIdOrName != "" {
+		ipsBlock = doc.IPsBlockByIdOrName(ipBlockIdOrName)
+		if ipsBlock == nil && !createNamedIfMissing {
+			return nil, fmt.Errorf("aliases block '%s' was not found", ipBlockIdOrName)
+		}
+	}
+
+	// #2 try to find last ips block
+	if ipsBlock == nil && ipBlockIdOrName == "" {
+		blocks := doc.IPBlocks()
+
+		if len(blocks) > 0 {
+			lastIPsBlock := blocks[len(blocks)-1]
+			var lastIPBlockIfx dom.Block = lastIPsBlock
+
+			allBlocks := doc.Blocks()
+			lastIndex := slices.Index(allBlocks, lastIPBlockIfx)
+			foundBreakingBlock := false
+			for i := lastIndex + 1; i < len(allBlocks); i++ {
+				blockType := allBlocks[i].Type()
+				shouldBrak := false
+				switch blockType {
+				case dom.Blanks:
+					continue
+				default:
+					foundBreakingBlock = true
+					shouldBrak = true
+				}
+				if shouldBrak {
+					break
+				}
+			}
+			if !foundBreakingBlock {
+				ipsBlock = lastIPsBlock
+			}
+		}
+
+	}
+
+	// #3 try to create a new block
+	if ipsBlock == nil {
+		ipsBlock = dom.NewIPAliasesBlock()
+		if ipBlockIdOrName != "" {
+			v, err := strconv.Atoi(ipBlockIdOrName)
+			if err == nil {
+				ipsBlock.SetId(v)
+			} else {
+				ipsBlock.SetName(ipBlockIdOrName)
+			}
+		}
+		doc.AddBlock(ipsBlock)
+	}
+	return ipsBlock, nil
+}
+
+// Deletes the blocks together with the blank lines following them,
+// the blank lines preceding the last block of the document are deleted with it
+func DeleteBlocks(doc *dom.Document, targetBlocks []*dom.IPAliasesBlock) {
+	for _, b := range targetBlocks {
+		blocks := doc.Blocks()
+
+		var mainBlock, spaceBlock dom.Block
+
+		mainBlock = b
+		idx := slices.Index(blocks, mainBlock)
+		if len(blocks) > idx+1 {
+			spaceBlock = blocks[idx+1]
+		} else if idx > 0 {
+			spaceBlock = blocks[idx-1]
+		}
+
+		doc.DeleteBlock(mainBlock)
+		if spaceBlock != nil && spaceBlock.Type() == dom.Blanks {
+			doc.DeleteBlock(spaceBlock)
+		}
+	}
+}
@@ -1,9 +1,15 @@
 package common
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
 )
 
+// Exit code of commands run with --dry-run --exit-code when the hosts file would be changed
+const ExitCodeChanges = 2
+
 type GlobalOptions struct {
 	OutputFormat string
 }
@@ -14,8 +20,52 @@ type CliCommand interface {
 	Execute() error
 }
 
+// Command which can be run without changing the hosts file
+type DryRunCommand interface {
+	CliCommand
+	DryRun() *DryRunOptions
+}
+
+// Options of commands supporting dry run mode
+type DryRunOptions struct {
+	DryRun   bool
+	ExitCode bool
+}
+
+// Registers --dry-run and --exit-code flags
+func AddDryRunFlags(cmd *cobra.Command, opt *DryRunOptions) {
+	cmd.Flags().BoolVar(&opt.DryRun, "dry-run", opt.DryRun, "Do not change the hosts file, print the changes as unified diff instead")
+	cmd.Flags().BoolVar(&opt.ExitCode, "exit-code", opt.ExitCode, fmt.Sprintf("Used with --dry-run, exit with code %d if the hosts file would be changed", ExitCodeChanges))
+}
+
 func RunCliCommand(cliCmd CliCommand, cmd *cobra.Command, args []string) {
 	cobra.CheckErr(cliCmd.Complete(cmd, args))
 	cobra.CheckErr(cliCmd.Validate())
+
+	if drc, ok := cliCmd.(DryRunCommand); ok {
+		dro := drc.DryRun()
+		if dro.ExitCode && !dro.DryRun {
+			cobra.CheckErr(fmt.Errorf("--exit-code can be used with --dry-run only; %w", ErrWrongArgumentValue))
+		}
+		if dro.DryRun {
+			runDryRun(drc, cmd)
+			return
+		}
+	}
+
 	cobra.CheckErr(cliCmd.Execute())
 }
+
+// Executes the command collecting hosts file changes instead of saving them,
+// then prints the changes as unified diff
+func runDryRun(drc DryRunCommand, cmd *cobra.Command) {
+	rec := newDryRunRecorder()
+	cmd.SetContext(withDryRunRecorder(cmd.Context(), rec))
+
+	cobra.CheckErr(drc.Execute())
+
+	changed := rec.WriteDiff(cmd.OutOrStdout())
+	if changed && drc.DryRun().ExitCode {
+		os.Exit(ExitCodeChanges)
+	}
+}
@@ -2,7 +2,18 @@ package common
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/backup"
+	"github.com/0xcfff/hostsctl/hosts/journal"
+	"github.com/0xcfff/hostsctl/iptools"
 	"github.com/spf13/afero"
 )
 
@@ -10,6 +21,27 @@ type commandContextValue int
 
 const (
 	ctxCustomFileSystem commandContextValue = iota
+	ctxCustomHostsFile
+	ctxLockTimeout
+	ctxCustomBackupDir
+	ctxCommandLine
+	ctxDryRunRecorder
+	ctxSystemAliases
+	ctxClock
+)
+
+const (
+	// Environment variable which overrides hosts file location
+	EnvHostsFile = "HOSTSCTL_HOSTS_FILE"
+
+	// Environment variable which overrides backups directory location
+	EnvBackupDir = "HOSTSCTL_BACKUP_DIR"
+
+	// Environment variable with additional system aliases, e.g. "10.8.0.1=vpn-gateway,::1=dev-box"
+	EnvSystemAliases = "HOSTSCTL_SYSTEM_ALIASES"
+
+	// Default time to wait for hosts file lock held by another process
+	DefaultLockTimeout = 10 * time.Second
 )
 
 // Overrides filesystem used by commands
@@ -25,3 +57,150 @@ func FileSystem(ctx context.Context) afero.Fs {
 	}
 	return nil
 }
+
+// Overrides hosts file path used by commands
+func WithCustomHostsFile(ctx context.Context, path string) context.Context {
+	return context.WithValue(ctx, ctxCustomHostsFile, path)
+}
+
+// Returns hosts file path override if any, otherwise default hosts file path
+func HostsFile(ctx context.Context) string {
+	po := ctx.Value(ctxCustomHostsFile)
+	if po != nil && po.(string) != "" {
+		return po.(string)
+	}
+	return hosts.EtcHosts.Path()
+}
+
+// Returns hosts file source taking into account path and filesystem overrides
+func HostsSource(ctx context.Context) *hosts.Source {
+	return hosts.NewSource(HostsFile(ctx), FileSystem(ctx))
+}
+
+// Overrides current time seen by commands
+func WithClock(ctx context.Context, now func() time.Time) context.Context {
+	return context.WithValue(ctx, ctxClock, now)
+}
+
+// Returns current time taking into account clock override
+func Now(ctx context.Context) time.Time {
+	now := ctx.Value(ctxClock)
+	if now != nil {
+		return now.(func() time.Time)()
+	}
+	return time.Now()
+}
+
+// Overrides time to wait for hosts file lock
+func WithLockTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, ctxLockTimeout, timeout)
+}
+
+// Returns hosts file lock timeout override if any, otherwise default timeout
+func LockTimeout(ctx context.Context) time.Duration {
+	to := ctx.Value(ctxLockTimeout)
+	if to != nil {
+		return to.(time.Duration)
+	}
+	return DefaultLockTimeout
+}
+
+// Returns hosts file source locked for modification, changes saved through the source are journaled.
+// In dry run mode the source is not locked and the changes are recorded instead of being saved.
+// The caller is responsible for calling Unlock on the source
+func LockedHostsSource(ctx context.Context) (*hosts.Source, error) {
+	src := HostsSource(ctx)
+	if rec := dryRunRecorderFrom(ctx); rec != nil {
+		src.SetDryRun(true)
+		src.SetSaveHook(func(before []byte, after []byte) error {
+			rec.Record(src.Path(), before, after)
+			return nil
+		})
+		return src, nil
+	}
+
+	err := src.Lock(LockTimeout(ctx))
+	if err != nil {
+		return nil, err
+	}
+	src.SetSaveHook(func(before []byte, after []byte) error {
+		_, err := Journal(ctx).Record(CommandLine(ctx), before, after)
+		return err
+	})
+	return src, nil
+}
+
+// Overrides catalog of system aliases used by commands
+func WithSystemAliases(ctx context.Context, catalog *iptools.SystemAliasCatalog) context.Context {
+	return context.WithValue(ctx, ctxSystemAliases, catalog)
+}
+
+// Returns system aliases catalog override if any, otherwise catalog of the current platform and hostname
+func SystemAliases(ctx context.Context) *iptools.SystemAliasCatalog {
+	co := ctx.Value(ctxSystemAliases)
+	if co != nil {
+		return co.(*iptools.SystemAliasCatalog)
+	}
+	return iptools.DefaultSystemAliases()
+}
+
+// Parses system aliases in the "ip=alias" form separated by commas or spaces and adds them to the catalog
+func AddSystemAliases(catalog *iptools.SystemAliasCatalog, value string) error {
+	items := strings.FieldsFunc(value, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+	for _, item := range items {
+		ip, alias, found := strings.Cut(item, "=")
+		if !found {
+			return fmt.Errorf("%s is not in the ip=alias form; %w", item, ErrWrongArgumentValue)
+		}
+		if err := catalog.Add(ip, alias, iptools.SrcUser); err != nil {
+			return fmt.Errorf("%v; %w", err, ErrWrongArgumentValue)
+		}
+	}
+	return nil
+}
+
+// Overrides directory where hosts file backups are stored
+func WithCustomBackupDir(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, ctxCustomBackupDir, dir)
+}
+
+// Returns backups directory override if any, otherwise default directory next to the hosts file
+func BackupDir(ctx context.Context) string {
+	do := ctx.Value(ctxCustomBackupDir)
+	if do != nil && do.(string) != "" {
+		return do.(string)
+	}
+	return backup.DefaultDir(HostsFile(ctx))
+}
+
+// Returns hosts file backups store taking into account directory and filesystem overrides
+func BackupStore(ctx context.Context) *backup.Store {
+	return backup.NewStore(BackupDir(ctx), FileSystem(ctx))
+}
+
+// Returns hosts file changes journal, it is stored in the backups directory
+func Journal(ctx context.Context) *journal.Journal {
+	return journal.NewJournal(filepath.Join(BackupDir(ctx), "journal"), FileSystem(ctx))
+}
+
+// Overrides command line recorded in the changes journal
+func WithCommandLine(ctx context.Context, commandLine string) context.Context {
+	return context.WithValue(ctx, ctxCommandLine, commandLine)
+}
+
+// Returns command line override if any, otherwise the current process command line
+func CommandLine(ctx context.Context) string {
+	co := ctx.Value(ctxCommandLine)
+	if co != nil && co.(string) != "" {
+		return co.(string)
+	}
+
+	args := []string{filepath.Base(os.Args[0])}
+	for _, a := range os.Args[1:] {
+		if a == "" || strings.ContainsAny(a, " \t\"'") {
+			a = strconv.Quote(a)
+		}
+		args = append(args, a)
+	}
+	return strings.Join(args, " ")
+}
@@ -0,0 +1,39 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/iptools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddSystemAliases(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		system  [][2]string
+		wantErr string
+	}{
+		{"comma separated", "10.8.0.1=vpn-gateway,::1=dev-box", [][2]string{{"10.8.0.1", "vpn-gateway"}, {"::1", "dev-box"}}, ""},
+		{"space separated", " 10.8.0.1=vpn-gateway \n 10.8.0.2=VPN-DNS ", [][2]string{{"10.8.0.1", "vpn-gateway"}, {"10.8.0.2", "vpn-dns"}}, ""},
+		{"no separator", "10.8.0.1:vpn-gateway", nil, "10.8.0.1:vpn-gateway is not in the ip=alias form; wrong argument value"},
+		{"wrong ip", "10.8.0.999=vpn-gateway", nil, "wrong argument value"},
+		{"wrong alias", "10.8.0.1=vpn_gateway", nil, "invalid host name; wrong argument value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			catalog := iptools.NewSystemAliasCatalog("linux", "")
+			err := AddSystemAliases(catalog, tt.value)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			for _, s := range tt.system {
+				sa, ok := catalog.Lookup(s[0], s[1])
+				assert.True(t, ok, s)
+				assert.Equal(t, iptools.SrcUser, sa.Source)
+			}
+		})
+	}
+}
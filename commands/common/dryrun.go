@@ -0,0 +1,64 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/0xcfff/hostsctl/iotools"
+)
+
+// Collects hosts file changes made in dry run mode
+type dryRunRecorder struct {
+	paths   []string
+	changes map[string]*dryRunChange
+}
+
+type dryRunChange struct {
+	before []byte
+	after  []byte
+}
+
+func newDryRunRecorder() *dryRunRecorder {
+	return &dryRunRecorder{
+		paths:   make([]string, 0),
+		changes: make(map[string]*dryRunChange),
+	}
+}
+
+// Records the change, subsequent changes of the same file are merged
+func (r *dryRunRecorder) Record(path string, before []byte, after []byte) {
+	ch, ok := r.changes[path]
+	if !ok {
+		r.paths = append(r.paths, path)
+		r.changes[path] = &dryRunChange{before: before, after: after}
+		return
+	}
+	ch.after = after
+}
+
+// Prints recorded changes as unified diff, returns true if there are any changes
+func (r *dryRunRecorder) WriteDiff(w io.Writer) bool {
+	changed := false
+	for _, path := range r.paths {
+		ch := r.changes[path]
+		diff := iotools.UnifiedDiff(path, ch.before, path, ch.after)
+		if diff != "" {
+			changed = true
+			fmt.Fprint(w, diff)
+		}
+	}
+	return changed
+}
+
+func withDryRunRecorder(ctx context.Context, rec *dryRunRecorder) context.Context {
+	return context.WithValue(ctx, ctxDryRunRecorder, rec)
+}
+
+func dryRunRecorderFrom(ctx context.Context) *dryRunRecorder {
+	rec := ctx.Value(ctxDryRunRecorder)
+	if rec != nil {
+		return rec.(*dryRunRecorder)
+	}
+	return nil
+}
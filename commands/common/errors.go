@@ -14,5 +14,7 @@ var (
 	ErrWrongArgumentValue       = errors.New("wrong argument value")
 	ErrBlockNotFound            = errors.New("block not found")
 	ErrAliasNotFound            = errors.New("alias not found")
+	ErrProfileNotFound          = errors.New("profile not found")
 	ErrNotSupportedOutputFormat = errors.New("not supported output format")
+	ErrBlockLocked              = errors.New("block is locked")
 )
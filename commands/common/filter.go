@@ -0,0 +1,90 @@
+package common
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/0xcfff/hostsctl/iptools"
+)
+
+// Single value of the list commands [filter] argument.
+// The value is treated as IP address, CIDR network, range of IP addresses, regular expression
+// if it is enclosed into slashes (e.g. /^api[0-9]+\./) or a glob pattern otherwise
+type FilterTerm struct {
+	addrs *iptools.AddrRange
+	rx    *regexp.Regexp
+	glob  string
+}
+
+// Parses filter value
+func ParseFilterTerm(value string) (*FilterTerm, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, fmt.Errorf("empty filter; %w", ErrWrongArgumentValue)
+	}
+
+	if addrs, err := iptools.ParseAddrRange(value); err == nil {
+		return &FilterTerm{addrs: &addrs}, nil
+	}
+
+	if len(value) > 1 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/") {
+		rx, err := regexp.Compile("(?i)" + value[1:len(value)-1])
+		if err != nil {
+			return nil, fmt.Errorf("can't parse filter %s, %v; %w", value, err, ErrWrongArgumentValue)
+		}
+		return &FilterTerm{rx: rx}, nil
+	}
+
+	glob := strings.ToLower(value)
+	if _, err := path.Match(glob, ""); err != nil {
+		return nil, fmt.Errorf("can't parse filter %s, %v; %w", value, err, ErrWrongArgumentValue)
+	}
+	return &FilterTerm{glob: glob}, nil
+}
+
+// Parses all filter values
+func ParseFilterTerms(values []string) ([]*FilterTerm, error) {
+	result := make([]*FilterTerm, 0, len(values))
+	for _, v := range values {
+		term, err := ParseFilterTerm(v)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, term)
+	}
+	return result, nil
+}
+
+// Returns true if the filter is an IP address, a network or a range
+func (t *FilterTerm) IsAddress() bool {
+	return t.addrs != nil
+}
+
+// Returns true if the IP is equal to the filter IP or belongs to the filter network or range
+func (t *FilterTerm) MatchIP(value string) bool {
+	if !t.IsAddress() {
+		return false
+	}
+	return t.addrs.ContainsString(value)
+}
+
+// Returns true if the name matches the filter glob pattern or regular expression
+func (t *FilterTerm) MatchName(value string) bool {
+	switch {
+	case t.rx != nil:
+		return t.rx.MatchString(value)
+	case t.glob != "":
+		matched, _ := path.Match(t.glob, strings.ToLower(value))
+		return matched
+	default:
+		return false
+	}
+}
+
+// Returns true if the text contains the substring ignoring case,
+// empty substring matches any text
+func ContainsFold(text string, substr string) bool {
+	return strings.Contains(strings.ToLower(text), strings.ToLower(substr))
+}
@@ -0,0 +1,53 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterTerm(t *testing.T) {
+	tests := []struct {
+		name      string
+		filter    string
+		value     string
+		isAddress bool
+		want      bool
+	}{
+		{"ip - same", "192.168.0.1", "192.168.0.1", true, true},
+		{"ip - other", "192.168.0.1", "192.168.0.10", true, false},
+		{"ip6 - different notation", "::1", "0:0:0:0:0:0:0:1", true, true},
+		{"network - contains", "10.0.0.0/8", "10.20.30.40", true, true},
+		{"network - not contains", "10.0.0.0/8", "11.20.30.40", true, false},
+		{"network - not an ip", "10.0.0.0/8", "localhost", true, false},
+		{"ip - leading zeros", "127.000.000.001", "127.0.0.1", true, true},
+		{"range - contains", "10.0.0.10-10.0.0.20", "10.0.0.15", true, true},
+		{"range - not contains", "10.0.0.10-10.0.0.20", "10.0.0.21", true, false},
+		{"glob - with hyphen", "pet-*", "pet-prj1", false, true},
+		{"glob - exact", "localhost", "localhost", false, true},
+		{"glob - mixed case", "*.Example.com", "api.EXAMPLE.com", false, true},
+		{"glob - not matched", "*.example.com", "example.com", false, false},
+		{"regex - matched", "/^api[0-9]+\\./", "api12.example.com", false, true},
+		{"regex - not matched", "/^api[0-9]+\\./", "web.api1.example.com", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term, err := ParseFilterTerm(tt.filter)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.isAddress, term.IsAddress())
+			if tt.isAddress {
+				assert.Equal(t, tt.want, term.MatchIP(tt.value))
+			} else {
+				assert.Equal(t, tt.want, term.MatchName(tt.value))
+			}
+		})
+	}
+}
+
+func TestParseFilterTerm_errors(t *testing.T) {
+	for _, filter := range []string{"", "/(api/", "[a-"} {
+		_, err := ParseFilterTerm(filter)
+		assert.ErrorIs(t, err, ErrWrongArgumentValue, filter)
+	}
+}
@@ -0,0 +1,60 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"golang.org/x/exp/slices"
+)
+
+// Block marked as locked when the hosts file was loaded
+type LockedBlock struct {
+	Block    *dom.IPAliasesBlock
+	Title    string
+	LockedBy string
+}
+
+// Returns blocks of the document marked as locked, the result should be taken
+// right after the document is loaded and passed to CheckLockedBlocks before it is saved
+func FindLockedBlocks(doc *dom.Document) []LockedBlock {
+	result := make([]LockedBlock, 0)
+	for _, blk := range doc.LockedBlocks() {
+		result = append(result, LockedBlock{
+			Block:    blk,
+			Title:    BlockTitle(blk),
+			LockedBy: blk.LockedBy(),
+		})
+	}
+	return result
+}
+
+// Returns error if any of the locked blocks was changed or deleted, unless forced
+func CheckLockedBlocks(doc *dom.Document, locked []LockedBlock, force bool) error {
+	if force {
+		return nil
+	}
+	blocks := doc.IPBlocks()
+	for _, lb := range locked {
+		if lb.Block.Modified() || !slices.Contains(blocks, lb.Block) {
+			return LockedBlockError(lb.Title, lb.LockedBy)
+		}
+	}
+	return nil
+}
+
+// Returns error telling the block is locked and by whom
+func LockedBlockError(title string, lockedBy string) error {
+	by := ""
+	if lockedBy != "" {
+		by = " by " + lockedBy
+	}
+	return fmt.Errorf("block %s is locked%s, use --force to change it; %w", title, by, ErrBlockLocked)
+}
+
+// Returns block ID and name as written in the block header
+func BlockTitle(blk *dom.IPAliasesBlock) string {
+	if blk.Name() == "" {
+		return fmt.Sprintf("[%d]", blk.Id())
+	}
+	return fmt.Sprintf("[%d] %s", blk.Id(), blk.Name())
+}
@@ -3,28 +3,35 @@ package database
 import (
 	"errors"
 	"fmt"
-	"io"
-	"os"
+	"time"
 
 	"github.com/0xcfff/hostsctl/commands/common"
-	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/backup"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
+const defaultBackupsToKeep = 10
+
 type BackupOptions struct {
-	command *cobra.Command
-	output  string
-	force   bool
+	command     *cobra.Command
+	output      string
+	force       bool
+	description string
+	keep        int
+	keepFor     time.Duration
 }
 
 func NewCmdDatabaseBackup() *cobra.Command {
 
-	opt := &BackupOptions{}
+	opt := &BackupOptions{
+		keep: defaultBackupsToKeep,
+	}
 
 	cmd := &cobra.Command{
 		Use:   "backup [flags]",
 		Short: "Backups IP aliases database",
+		Long:  "Backups IP aliases database into the backups directory, old backups are deleted according to the retention flags",
 		Run: func(cmd *cobra.Command, args []string) {
 			cobra.CheckErr(opt.Complete(cmd, args))
 			cobra.CheckErr(opt.Validate())
@@ -32,8 +39,13 @@ func NewCmdDatabaseBackup() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&opt.output, "output", "o", "", "backup file name")
-	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Do not fail if backup file already exists")
+	cmd.Flags().StringVarP(&opt.output, "output", "o", "", "Copy the database to the file instead of the backups directory")
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Overwrite the --output file if it already exists")
+	cmd.Flags().StringVarP(&opt.description, "description", "d", opt.description, "Backup description")
+	cmd.Flags().IntVar(&opt.keep, "keep", opt.keep, "Number of the most recent backups to keep, 0 keeps all of them")
+	cmd.Flags().DurationVar(&opt.keepFor, "keep-for", opt.keepFor, "Delete backups older than the duration (e.g. 720h), 0 keeps backups of any age")
+
+	cmd.AddCommand(NewCmdDatabaseBackupList())
 
 	return cmd
 }
@@ -49,44 +61,47 @@ func (opt *BackupOptions) Validate() error {
 	if len(args) > 0 {
 		return common.ErrTooManyArguments
 	}
+	if opt.keep < 0 {
+		return fmt.Errorf("keep: %d; %w", opt.keep, common.ErrWrongArgumentValue)
+	}
+	if opt.keepFor < 0 {
+		return fmt.Errorf("keep-for: %s; %w", opt.keepFor, common.ErrWrongArgumentValue)
+	}
 	return nil
 }
 
 func (opt *BackupOptions) Execute() error {
+	ctx := opt.command.Context()
+
+	src := common.HostsSource(ctx)
+	data, err := src.LoadRaw()
+	cobra.CheckErr(err)
+
+	if opt.output != "" {
+		return opt.copyTo(data)
+	}
+
+	store := common.BackupStore(ctx)
+	snap, err := store.Create(data, src.Path(), opt.description)
+	cobra.CheckErr(err)
+
+	_, err = store.Prune(backup.RetentionPolicy{KeepLast: opt.keep, KeepFor: opt.keepFor})
+	cobra.CheckErr(err)
+
+	fmt.Fprintln(opt.command.OutOrStdout(), snap.Id)
+
+	return nil
+}
 
-	sourcePath := hosts.EtcHosts.Path()
-	targetPath := opt.output
-	if targetPath == "" {
-		targetPath = fmt.Sprintf("%s.bak", sourcePath)
+func (opt *BackupOptions) copyTo(data []byte) error {
+	fs := common.FileSystem(opt.command.Context())
+	if fs == nil {
+		fs = afero.NewOsFs()
 	}
 
-	if _, err := os.Stat(targetPath); err == nil {
-		if !opt.force {
-			return errors.New("backup file already exists")
-		}
-		os.Remove(targetPath)
+	if exists, _ := afero.Exists(fs, opt.output); exists && !opt.force {
+		return errors.New("backup file already exists")
 	}
 
-	src := hosts.NewSource(sourcePath, common.FileSystem(opt.command.Context()))
-	err := src.Apply(func(path string, fs afero.Fs) error {
-		sf, err := fs.Open(path)
-		if err != nil {
-			return err
-		}
-		defer sf.Close()
-
-		tf, err := fs.OpenFile(targetPath, os.O_CREATE|os.O_RDWR, 0o644)
-		if err != nil {
-			return err
-		}
-		defer tf.Close()
-
-		_, err = io.Copy(tf, sf)
-		if err != nil {
-			return err
-		}
-
-		return nil
-	})
-	return err
+	return afero.WriteFile(fs, opt.output, data, 0o644)
 }
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts/backup"
+	"github.com/0xcfff/hostsctl/iotools"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+type listFormat int
+
+const (
+	lfmtText listFormat = iota
+	lfmtWide listFormat = iota
+	lfmtJson listFormat = iota
+	lfmtYaml listFormat = iota
+)
+
+var (
+	listFormats = map[string]listFormat{
+		"":              lfmtText,
+		common.TfmtText: lfmtText,
+		"wide":          lfmtWide,
+		common.TfmtJson: lfmtJson,
+		common.TfmtYaml: lfmtYaml,
+	}
+)
+
+type BackupListOptions struct {
+	command      *cobra.Command
+	output       string
+	outputFormat listFormat
+	noHeaders    bool
+}
+
+func NewCmdDatabaseBackupList() *cobra.Command {
+
+	opt := &BackupListOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "list [(-o|--output)=name]",
+		Short:   "Lists IP aliases database backups",
+		Aliases: []string{"ls"},
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(opt.Complete(cmd, args))
+			cobra.CheckErr(opt.Validate())
+			cobra.CheckErr(opt.Execute())
+		},
+	}
+
+	cmd.Flags().BoolVar(&opt.noHeaders, "no-headers", opt.noHeaders, "Disable printing headers")
+	cmd.Flags().StringVarP(&opt.output, "output", "o", opt.output, fmt.Sprintf("Output format. One of %s", strings.Join(maps.Keys(listFormats), ",")))
+
+	return cmd
+}
+
+func (opt *BackupListOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	var ok bool
+	opt.outputFormat, ok = listFormats[opt.output]
+	if !ok {
+		return fmt.Errorf("value %v is not support; %w", opt.output, common.ErrNotSupportedOutputFormat)
+	}
+
+	return nil
+}
+
+func (opt *BackupListOptions) Validate() error {
+	args := opt.command.Flags().Args()
+	if len(args) > 0 {
+		return common.ErrTooManyArguments
+	}
+	return nil
+}
+
+func (opt *BackupListOptions) Execute() error {
+	snaps, err := common.BackupStore(opt.command.Context()).List()
+	cobra.CheckErr(err)
+
+	switch opt.outputFormat {
+	case lfmtText, lfmtWide:
+		err = writeBackupsAsText(opt, snaps)
+	case lfmtJson:
+		err = writeBackupsAsJson(opt, snaps)
+	case lfmtYaml:
+		err = writeBackupsAsYaml(opt, snaps)
+	default:
+		panic("unknown output format")
+	}
+	cobra.CheckErr(err)
+
+	return nil
+}
+
+func writeBackupsAsText(opt *BackupListOptions, snaps []*backup.Snapshot) error {
+	err := iotools.PrintTabbed(opt.command.OutOrStdout(), nil, 2, func(w io.Writer) error {
+
+		if !opt.noHeaders {
+			columns := []string{"ID", "CREATED", "DESCRIPTION", "SIZE", "CHECKSUM"}
+			visible := getVisibleBackupValues(opt, columns)
+			fmt.Fprint(w, strings.Join(visible, "\t"))
+			fmt.Fprintln(w)
+		}
+
+		for _, s := range snaps {
+			values := []string{s.Id, s.Created.Local().Format(time.DateTime), s.Description, strconv.FormatInt(s.Size, 10), s.Checksum}
+
+			visible := getVisibleBackupValues(opt, values)
+			fmt.Fprint(w, strings.Join(visible, "\t"))
+			fmt.Fprintln(w)
+		}
+		return nil
+	})
+	return err
+}
+
+func writeBackupsAsJson(opt *BackupListOptions, snaps []*backup.Snapshot) error {
+	buff, err := json.Marshal(snaps)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opt.command.OutOrStdout(), string(buff))
+	return nil
+}
+
+func writeBackupsAsYaml(opt *BackupListOptions, snaps []*backup.Snapshot) error {
+	buff, err := yaml.Marshal(snaps)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opt.command.OutOrStdout(), string(buff))
+	return nil
+}
+
+func getVisibleBackupValues(opt *BackupListOptions, values []string) []string {
+	// "ID", "CREATED", "DESCRIPTION", "SIZE", "CHECKSUM"
+	switch opt.outputFormat {
+	case lfmtText:
+		return values[:3]
+	case lfmtWide:
+		return values
+	default:
+		panic("unsupported formatting")
+	}
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestBackupListCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "backup list - empty",
+			Args: cmdtest.ITArgs{
+				Args:      []string{},
+				InputFile: "testdata/empty.txt",
+				Stdout:    "ID  CREATED  DESCRIPTION\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "backup list json - two backups",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-o", "json"},
+				InputFile:  "testdata/empty.txt",
+				ExtraFiles: backupFiles,
+				StdoutFile: "testdata/backup/list_json__two_backups__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "backup list yaml - two backups",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-o", "yaml"},
+				InputFile:  "testdata/empty.txt",
+				ExtraFiles: backupFiles,
+				StdoutFile: "testdata/backup/list_yaml__two_backups__output.txt",
+			},
+			Want: true,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestBackupListCommand", func() *cobra.Command { return NewCmdDatabaseBackupList() })
+}
+
+func TestBackupCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "backup - copy to file",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-o", "/tmp/hosts.copy"},
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/four-blocks.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "backup - overwrite file",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-o", "/tmp/hosts.copy", "--force"},
+				InputFile:  "testdata/four-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/hosts.copy": "testdata/six-blocks.txt"},
+				OutputFile: "testdata/four-blocks.txt",
+			},
+			Want: true,
+		},
+
+		// errors cases
+		{
+			Name: "backup error - file exists",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-o", "/tmp/hosts.copy"},
+				InputFile:  "testdata/four-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/hosts.copy": "testdata/six-blocks.txt"},
+				ErrorText:  "backup file already exists",
+			},
+			Want: false,
+		},
+		{
+			Name: "backup error - negative keep",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"--keep", "-1"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "keep: -1; wrong argument value",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestBackupCommand", func() *cobra.Command { return NewCmdDatabaseBackup() })
+}
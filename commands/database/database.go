@@ -16,6 +16,11 @@ func NewCmdDatabase() *cobra.Command {
 	cmd.AddCommand(NewCmdDatabaseLocation())
 	cmd.AddCommand(NewCmdDatabaseBackup())
 	cmd.AddCommand(NewCmdDatabaseRestore())
+	cmd.AddCommand(NewCmdDatabaseUndo())
+	cmd.AddCommand(NewCmdDatabaseRedo())
+	cmd.AddCommand(NewCmdDatabaseHistory())
+	cmd.AddCommand(NewCmdDatabaseDiff())
+	cmd.AddCommand(NewCmdDatabaseLint())
 
 	return cmd
 }
@@ -0,0 +1,239 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/backup"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/0xcfff/hostsctl/iotools"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	refLive   = "live"
+	refLatest = "latest"
+)
+
+var (
+	diffFormats = map[string]common.OutputFormat{
+		"":              common.FmtText,
+		common.TfmtText: common.FmtText,
+		common.TfmtJson: common.FmtJson,
+		common.TfmtYaml: common.FmtYaml,
+	}
+)
+
+type DiffOptions struct {
+	command      *cobra.Command
+	output       string
+	outputFormat common.OutputFormat
+	unified      bool
+	noHeaders    bool
+	from         string
+	to           string
+}
+
+// Hosts document to compare
+type diffSide struct {
+	label string
+	data  []byte
+}
+
+func NewCmdDatabaseDiff() *cobra.Command {
+
+	opt := &DiffOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "diff [from] [to]",
+		Short: "Shows differences between IP aliases databases",
+		Long: `Shows differences between IP aliases databases.
+
+Each argument is one of:
+  live        the hosts file (default for [to])
+  latest      the most recent backup (default for [from])
+  <backup id> backup listed by 'database backup list'
+  <path>      any hosts file
+
+IP aliases blocks and entries are compared, if they are the same but the files
+are not, the difference is shown as unified diff`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(opt.Complete(cmd, args))
+			cobra.CheckErr(opt.Validate())
+			cobra.CheckErr(opt.Execute())
+		},
+	}
+
+	cmd.Flags().BoolVar(&opt.noHeaders, "no-headers", opt.noHeaders, "Disable printing headers")
+	cmd.Flags().BoolVarP(&opt.unified, "unified", "u", opt.unified, "Show unified diff of the files instead of the semantic differences")
+	cmd.Flags().StringVarP(&opt.output, "output", "o", opt.output, fmt.Sprintf("Output format. One of %s", strings.Join(maps.Keys(diffFormats), ",")))
+
+	return cmd
+}
+
+func (opt *DiffOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	var ok bool
+	opt.outputFormat, ok = diffFormats[opt.output]
+	if !ok {
+		return fmt.Errorf("value %v is not support; %w", opt.output, common.ErrNotSupportedOutputFormat)
+	}
+
+	opt.from = refLatest
+	opt.to = refLive
+	if len(args) > 0 {
+		opt.from = args[0]
+	}
+	if len(args) > 1 {
+		opt.to = args[1]
+	}
+
+	return nil
+}
+
+func (opt *DiffOptions) Validate() error {
+	args := opt.command.Flags().Args()
+	if len(args) > 2 {
+		return common.ErrTooManyArguments
+	}
+	return nil
+}
+
+func (opt *DiffOptions) Execute() error {
+	from, err := opt.resolve(opt.from)
+	cobra.CheckErr(err)
+	to, err := opt.resolve(opt.to)
+	cobra.CheckErr(err)
+
+	m, err := NewDiffModel(from, to, opt.unified)
+	cobra.CheckErr(err)
+
+	switch opt.outputFormat {
+	case common.FmtText:
+		err = writeDiffAsText(opt, m)
+	case common.FmtJson:
+		err = writeDiffAsJson(opt, m)
+	case common.FmtYaml:
+		err = writeDiffAsYaml(opt, m)
+	default:
+		panic("unknown output format")
+	}
+	cobra.CheckErr(err)
+
+	return nil
+}
+
+// Loads hosts document referenced by the diff argument
+func (opt *DiffOptions) resolve(ref string) (*diffSide, error) {
+	ctx := opt.command.Context()
+
+	if ref == refLive {
+		src := common.HostsSource(ctx)
+		data, err := src.LoadRaw()
+		return &diffSide{label: src.Path(), data: data}, err
+	}
+
+	store := common.BackupStore(ctx)
+	var snap *backup.Snapshot
+	var err error
+	if ref == refLatest {
+		snap, err = store.Latest()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		snap, _ = store.Get(ref)
+	}
+	if snap != nil {
+		data, err := store.Read(snap)
+		return &diffSide{label: fmt.Sprintf("backup %s", snap.Id), data: data}, err
+	}
+
+	data, err := hosts.NewSource(ref, common.FileSystem(ctx)).LoadRaw()
+	return &diffSide{label: ref, data: data}, err
+}
+
+func writeDiffAsText(opt *DiffOptions, m *DiffModel) error {
+	out := opt.command.OutOrStdout()
+	if m.Text != "" {
+		fmt.Fprint(out, m.Text)
+		return nil
+	}
+	if len(m.Changes) == 0 {
+		return nil
+	}
+
+	return iotools.PrintTabbed(out, nil, 2, func(w io.Writer) error {
+		if !opt.noHeaders {
+			fmt.Fprintln(w, strings.Join([]string{"CHANGE", "BLOCK", "IP", "ALIAS"}, "\t"))
+		}
+		for _, c := range m.Changes {
+			block := c.Block.String()
+			if c.OldBlock != nil {
+				block = fmt.Sprintf("%s -> %s", c.OldBlock, block)
+			}
+			ip := c.IP
+			if c.OldIP != "" {
+				ip = fmt.Sprintf("%s -> %s", c.OldIP, c.IP)
+			}
+			fmt.Fprintln(w, strings.Join([]string{string(c.Kind), block, ip, c.Alias}, "\t"))
+		}
+		return nil
+	})
+}
+
+func writeDiffAsJson(opt *DiffOptions, m *DiffModel) error {
+	buff, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opt.command.OutOrStdout(), string(buff))
+	return nil
+}
+
+func writeDiffAsYaml(opt *DiffOptions, m *DiffModel) error {
+	buff, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opt.command.OutOrStdout(), string(buff))
+	return nil
+}
+
+// Compares the documents semantically, falls back to unified diff
+// if the documents differ by comments or formatting only
+func NewDiffModel(from *diffSide, to *diffSide, unified bool) (*DiffModel, error) {
+	m := &DiffModel{
+		From:    from.label,
+		To:      to.label,
+		Changes: make([]*ChangeModel, 0),
+	}
+
+	if !unified {
+		fromDoc, err := dom.Read(bytes.NewReader(from.data))
+		if err != nil {
+			return nil, fmt.Errorf("can't parse %s, %w", from.label, err)
+		}
+		toDoc, err := dom.Read(bytes.NewReader(to.data))
+		if err != nil {
+			return nil, fmt.Errorf("can't parse %s, %w", to.label, err)
+		}
+		for _, c := range dom.Diff(fromDoc, toDoc) {
+			m.Changes = append(m.Changes, newChangeModel(c))
+		}
+	}
+
+	if len(m.Changes) == 0 {
+		m.Text = iotools.UnifiedDiff(from.label, from.data, to.label, to.data)
+	}
+	return m, nil
+}
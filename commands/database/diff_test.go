@@ -0,0 +1,95 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestDiffCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "diff - live and latest backup",
+			Args: cmdtest.ITArgs{
+				Args:       []string{},
+				InputFile:  "testdata/five-blocks.txt",
+				ExtraFiles: backupFiles,
+				StdoutFile: "testdata/diff/diff__latest_live__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "diff - backup id and file",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"20260101T100000.000000Z", "/tmp/hosts.new"},
+				InputFile:  "testdata/empty.txt",
+				ExtraFiles: map[string]string{"/tmp/hosts.new": "testdata/five-blocks.txt", "/etc/hosts.backups/20260101T100000.000000Z.json": "testdata/backups/20260101T100000.000000Z.json", "/etc/hosts.backups/20260101T100000.000000Z.hosts": "testdata/backups/20260101T100000.000000Z.hosts"},
+				StdoutFile: "testdata/diff/diff__id_file__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "diff json - files",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"/tmp/hosts.old", "live", "-o", "json"},
+				InputFile:  "testdata/five-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/hosts.old": "testdata/six-blocks.txt"},
+				StdoutFile: "testdata/diff/diff_json__files__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "diff - comments only changed",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"/tmp/hosts.old"},
+				InputFile:  "testdata/diff/four-blocks-commented.txt",
+				ExtraFiles: map[string]string{"/tmp/hosts.old": "testdata/four-blocks.txt"},
+				StdoutFile: "testdata/diff/diff__comments__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "diff - same files",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"/tmp/hosts.old"},
+				InputFile:  "testdata/four-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/hosts.old": "testdata/four-blocks.txt"},
+				Stdout:     "",
+			},
+			Want: true,
+		},
+		{
+			Name: "diff yaml - unified",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"/tmp/hosts.old", "-u", "-o", "yaml"},
+				InputFile:  "testdata/five-blocks.txt",
+				ExtraFiles: map[string]string{"/tmp/hosts.old": "testdata/six-blocks.txt"},
+				StdoutFile: "testdata/diff/diff_yaml__unified__output.txt",
+			},
+			Want: true,
+		},
+
+		// errors cases
+		{
+			Name: "diff error - no backups",
+			Args: cmdtest.ITArgs{
+				Args:      []string{},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "no backups found",
+			},
+			Want: false,
+		},
+		{
+			Name: "diff error - file not found",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"/tmp/not-existing"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "can't read hosts file /tmp/not-existing",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestDiffCommand", func() *cobra.Command { return NewCmdDatabaseDiff() })
+}
@@ -1,15 +1,17 @@
 package database
 
 import (
+	"bytes"
+
 	"github.com/0xcfff/hostsctl/commands/common"
-	"github.com/0xcfff/hostsctl/hosts"
 	"github.com/0xcfff/hostsctl/hosts/dom"
 	"github.com/spf13/cobra"
 )
 
 type FormatOptions struct {
 	command *cobra.Command
-	dryRun  bool
+	force   bool
+	dryRun  common.DryRunOptions
 }
 
 func NewCmdDatabaseFormat() *cobra.Command {
@@ -17,20 +19,24 @@ func NewCmdDatabaseFormat() *cobra.Command {
 	opt := &FormatOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "format [--dry-run] [filter]",
+		Use:   "format [--dry-run]",
 		Short: "Formats the database",
 		Run: func(cmd *cobra.Command, args []string) {
-			cobra.CheckErr(opt.Complete(cmd, args))
-			cobra.CheckErr(opt.Validate())
-			cobra.CheckErr(opt.Execute())
+			common.RunCliCommand(opt, cmd, args)
 		},
 	}
 
-	cmd.Flags().BoolVar(&opt.dryRun, "dry-run", opt.dryRun, "Do not store formatting result, instead prints in to output")
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Format locked blocks too")
+
+	common.AddDryRunFlags(cmd, &opt.dryRun)
 
 	return cmd
 }
 
+func (opt *FormatOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
 func (opt *FormatOptions) Complete(cmd *cobra.Command, args []string) error {
 
 	opt.command = cmd
@@ -46,15 +52,58 @@ func (opt *FormatOptions) Validate() error {
 }
 
 func (opt *FormatOptions) Execute() error {
-	src := hosts.NewSource(hosts.EtcHosts.Path(), common.FileSystem(opt.command.Context()))
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
 	c, err := src.Load()
 	cobra.CheckErr(err)
 
-	if opt.dryRun {
-		dom.Write(opt.command.OutOrStdout(), c, dom.FmtReFormat)
-	} else {
-		src.Save(c, dom.FmtReFormat)
+	if !opt.force {
+		err = checkLockedBlocksFormatting(c)
+		cobra.CheckErr(err)
 	}
 
+	err = src.Save(c, dom.FmtReFormat)
+	cobra.CheckErr(err)
+
 	return nil
 }
+
+// Returns error if formatting changes lines of a locked block
+func checkLockedBlocksFormatting(doc *dom.Document) error {
+	buff := &bytes.Buffer{}
+	err := dom.Write(buff, doc, dom.FmtReFormat)
+	if err != nil {
+		return err
+	}
+	formatted, err := dom.Read(buff)
+	if err != nil {
+		return err
+	}
+
+	after := formatted.IPBlocks()
+	for i, blk := range doc.IPBlocks() {
+		if !blk.Locked() {
+			continue
+		}
+		if i >= len(after) || !sameEntryLines(blk, after[i]) {
+			return common.LockedBlockError(common.BlockTitle(blk), blk.LockedBy())
+		}
+	}
+	return nil
+}
+
+func sameEntryLines(blk *dom.IPAliasesBlock, other *dom.IPAliasesBlock) bool {
+	entries := blk.AliasEntries()
+	otherEntries := other.AliasEntries()
+	if len(entries) != len(otherEntries) {
+		return false
+	}
+	for i, ent := range entries {
+		if ent.LineText() != otherEntries[i].LineText() {
+			return false
+		}
+	}
+	return true
+}
@@ -36,6 +36,16 @@ func TestDatabaseFormatCommand(t *testing.T) {
 			},
 			Want: true,
 		},
+		{
+			Name: "format error - dry run, exit code, changes",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--dry-run", "--exit-code"},
+				InputFile:  "testdata/six-blocks.txt",
+				StdoutFile: "testdata/format/format_dry_run__non_empty__output.txt",
+				ExitCode:   2,
+			},
+			Want: false,
+		},
 		{
 			Name: "format error - too many arguments",
 			Args: cmdtest.ITArgs{
@@ -45,6 +55,33 @@ func TestDatabaseFormatCommand(t *testing.T) {
 			},
 			Want: false,
 		},
+		{
+			Name: "format - locked block already formatted",
+			Args: cmdtest.ITArgs{
+				Args:       []string{},
+				InputFile:  "testdata/locked-formatted-blocks.txt",
+				OutputFile: "testdata/format/format__locked_formatted__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "format locked - forced",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--force"},
+				InputFile:  "testdata/locked-blocks.txt",
+				OutputFile: "testdata/format/format_forced__locked__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "format error - locked block",
+			Args: cmdtest.ITArgs{
+				Args:      []string{},
+				InputFile: "testdata/locked-blocks.txt",
+				ErrorText: "block [15] pet-prj1 is locked by ansible",
+			},
+			Want: false,
+		},
 	}
 	cmdtest.RunIntergationTests(t, tests, "TestDatabaseFormatCommand", func() *cobra.Command { return NewCmdDatabaseFormat() })
 }
@@ -0,0 +1,136 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts/journal"
+	"github.com/0xcfff/hostsctl/iotools"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+type HistoryOptions struct {
+	command      *cobra.Command
+	output       string
+	outputFormat listFormat
+	noHeaders    bool
+}
+
+func NewCmdDatabaseHistory() *cobra.Command {
+
+	opt := &HistoryOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "history [(-o|--output)=name]",
+		Short: "Lists journaled IP aliases database changes",
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(opt.Complete(cmd, args))
+			cobra.CheckErr(opt.Validate())
+			cobra.CheckErr(opt.Execute())
+		},
+	}
+
+	cmd.Flags().BoolVar(&opt.noHeaders, "no-headers", opt.noHeaders, "Disable printing headers")
+	cmd.Flags().StringVarP(&opt.output, "output", "o", opt.output, fmt.Sprintf("Output format. One of %s", strings.Join(maps.Keys(listFormats), ",")))
+
+	return cmd
+}
+
+func (opt *HistoryOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	var ok bool
+	opt.outputFormat, ok = listFormats[opt.output]
+	if !ok {
+		return fmt.Errorf("value %v is not support; %w", opt.output, common.ErrNotSupportedOutputFormat)
+	}
+
+	return nil
+}
+
+func (opt *HistoryOptions) Validate() error {
+	args := opt.command.Flags().Args()
+	if len(args) > 0 {
+		return common.ErrTooManyArguments
+	}
+	return nil
+}
+
+func (opt *HistoryOptions) Execute() error {
+	entries, err := common.Journal(opt.command.Context()).History()
+	cobra.CheckErr(err)
+
+	switch opt.outputFormat {
+	case lfmtText, lfmtWide:
+		err = writeHistoryAsText(opt, entries)
+	case lfmtJson:
+		err = writeHistoryAsJson(opt, entries)
+	case lfmtYaml:
+		err = writeHistoryAsYaml(opt, entries)
+	default:
+		panic("unknown output format")
+	}
+	cobra.CheckErr(err)
+
+	return nil
+}
+
+func writeHistoryAsText(opt *HistoryOptions, entries []*journal.Entry) error {
+	err := iotools.PrintTabbed(opt.command.OutOrStdout(), nil, 2, func(w io.Writer) error {
+
+		if !opt.noHeaders {
+			columns := []string{"SEQ", "TIME", "STATE", "COMMAND", "BEFORE", "AFTER"}
+			visible := getVisibleHistoryValues(opt, columns)
+			fmt.Fprint(w, strings.Join(visible, "\t"))
+			fmt.Fprintln(w)
+		}
+
+		for _, e := range entries {
+			values := []string{strconv.Itoa(e.Seq), e.Time.Local().Format(time.DateTime), string(e.State), e.Command, e.Before, e.After}
+
+			visible := getVisibleHistoryValues(opt, values)
+			fmt.Fprint(w, strings.Join(visible, "\t"))
+			fmt.Fprintln(w)
+		}
+		return nil
+	})
+	return err
+}
+
+func writeHistoryAsJson(opt *HistoryOptions, entries []*journal.Entry) error {
+	buff, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opt.command.OutOrStdout(), string(buff))
+	return nil
+}
+
+func writeHistoryAsYaml(opt *HistoryOptions, entries []*journal.Entry) error {
+	buff, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opt.command.OutOrStdout(), string(buff))
+	return nil
+}
+
+func getVisibleHistoryValues(opt *HistoryOptions, values []string) []string {
+	// "SEQ", "TIME", "STATE", "COMMAND", "BEFORE", "AFTER"
+	switch opt.outputFormat {
+	case lfmtText:
+		return values[:4]
+	case lfmtWide:
+		return values
+	default:
+		panic("unsupported formatting")
+	}
+}
@@ -0,0 +1,190 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/lint"
+	"github.com/0xcfff/hostsctl/hosts/syntax"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+type lintFormat int
+
+const (
+	lintfmtText  lintFormat = iota
+	lintfmtJson  lintFormat = iota
+	lintfmtYaml  lintFormat = iota
+	lintfmtSarif lintFormat = iota
+)
+
+const stdinFile = "-"
+
+var (
+	lintFormats = map[string]lintFormat{
+		"":              lintfmtText,
+		common.TfmtText: lintfmtText,
+		common.TfmtJson: lintfmtJson,
+		common.TfmtYaml: lintfmtYaml,
+		"sarif":         lintfmtSarif,
+	}
+)
+
+type LintOptions struct {
+	command      *cobra.Command
+	output       string
+	outputFormat lintFormat
+	strict       bool
+	file         string
+}
+
+func NewCmdDatabaseLint() *cobra.Command {
+
+	opt := &LintOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "lint [file]",
+		Short:   "Checks IP aliases database for problems",
+		Aliases: []string{"validate"},
+		Long: `Checks IP aliases database for problems.
+
+The hosts file is checked if no file is specified, use - to read the file from stdin.
+The command fails if errors are found, or if any problems are found in strict mode.
+
+Checks:
+` + lintRulesHelp(),
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(opt.Complete(cmd, args))
+			cobra.CheckErr(opt.Validate())
+			cobra.CheckErr(opt.Execute())
+		},
+	}
+
+	cmd.Flags().BoolVar(&opt.strict, "strict", opt.strict, "Fail if warnings are found")
+	cmd.Flags().StringVarP(&opt.output, "output", "o", opt.output, fmt.Sprintf("Output format. One of %s", strings.Join(maps.Keys(lintFormats), ",")))
+
+	return cmd
+}
+
+func (opt *LintOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	var ok bool
+	opt.outputFormat, ok = lintFormats[opt.output]
+	if !ok {
+		return fmt.Errorf("value %v is not support; %w", opt.output, common.ErrNotSupportedOutputFormat)
+	}
+
+	if len(args) > 0 {
+		opt.file = args[0]
+	}
+
+	return nil
+}
+
+func (opt *LintOptions) Validate() error {
+	args := opt.command.Flags().Args()
+	if len(args) > 1 {
+		return common.ErrTooManyArguments
+	}
+	return nil
+}
+
+func (opt *LintOptions) Execute() error {
+	path, data, err := opt.read()
+	cobra.CheckErr(err)
+
+	doc, err := syntax.Read(bytes.NewReader(data))
+	cobra.CheckErr(err)
+
+	diagnostics := lint.Lint(doc)
+	m := NewLintModels(path, diagnostics)
+
+	switch opt.outputFormat {
+	case lintfmtText:
+		err = writeLintAsText(opt, m)
+	case lintfmtJson:
+		err = writeLintAsJson(opt, m)
+	case lintfmtYaml:
+		err = writeLintAsYaml(opt, m)
+	case lintfmtSarif:
+		err = writeLintAsSarif(opt, m)
+	default:
+		panic("unknown output format")
+	}
+	cobra.CheckErr(err)
+
+	if lint.HasErrors(diagnostics) || (opt.strict && len(diagnostics) > 0) {
+		cobra.CheckErr(fmt.Errorf("%d problem(s) found in %s", len(diagnostics), path))
+	}
+
+	return nil
+}
+
+// Reads the file to check
+func (opt *LintOptions) read() (string, []byte, error) {
+	ctx := opt.command.Context()
+
+	if opt.file == stdinFile {
+		data, err := io.ReadAll(opt.command.InOrStdin())
+		return "stdin", data, err
+	}
+
+	src := common.HostsSource(ctx)
+	if opt.file != "" {
+		src = hosts.NewSource(opt.file, common.FileSystem(ctx))
+	}
+	data, err := src.LoadRaw()
+	return src.Path(), data, err
+}
+
+func writeLintAsText(opt *LintOptions, m []*DiagnosticModel) error {
+	out := opt.command.OutOrStdout()
+	for _, d := range m {
+		fmt.Fprintf(out, "%s:%d:%d: %s: %s [%s]\n", d.File, d.Line, d.Column, d.Severity, d.Message, d.Rule)
+	}
+	return nil
+}
+
+func writeLintAsJson(opt *LintOptions, m []*DiagnosticModel) error {
+	buff, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opt.command.OutOrStdout(), string(buff))
+	return nil
+}
+
+func writeLintAsYaml(opt *LintOptions, m []*DiagnosticModel) error {
+	buff, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opt.command.OutOrStdout(), string(buff))
+	return nil
+}
+
+func writeLintAsSarif(opt *LintOptions, m []*DiagnosticModel) error {
+	buff, err := json.MarshalIndent(NewSarifLog(m), "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opt.command.OutOrStdout(), string(buff))
+	return nil
+}
+
+func lintRulesHelp() string {
+	sb := &strings.Builder{}
+	for _, r := range lint.Rules {
+		fmt.Fprintf(sb, "  %-22s %-9s %s\n", r.Id, r.Severity, r.Description)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
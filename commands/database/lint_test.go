@@ -0,0 +1,118 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestLintCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "lint - no problems",
+			Args: cmdtest.ITArgs{
+				Args:      []string{},
+				InputFile: "testdata/one-ip.txt",
+				Stdout:    "",
+			},
+			Want: true,
+		},
+		{
+			Name: "lint - file",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"/tmp/hosts.new"},
+				InputFile:  "testdata/empty.txt",
+				ExtraFiles: map[string]string{"/tmp/hosts.new": "testdata/five-blocks.txt"},
+				StdoutFile: "testdata/lint/lint__file__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "lint - stdin",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-"},
+				Stdin:      "10.0.0.1 cats.example.org\n10.0.0.1 cats.example.org\n",
+				InputFile:  "testdata/empty.txt",
+				StdoutFile: "testdata/lint/lint__stdin__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "lint json - warnings",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-o", "json"},
+				InputFile:  "testdata/four-blocks.txt",
+				StdoutFile: "testdata/lint/lint_json__warnings__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "lint yaml - warnings",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-o", "yaml"},
+				InputFile:  "testdata/four-blocks.txt",
+				StdoutFile: "testdata/lint/lint_yaml__warnings__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "lint sarif - warnings",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-o", "sarif"},
+				InputFile:  "testdata/four-blocks.txt",
+				StdoutFile: "testdata/lint/lint_sarif__warnings__output.txt",
+			},
+			Want: true,
+		},
+
+		// errors cases
+		{
+			Name: "error - invalid file",
+			Args: cmdtest.ITArgs{
+				Args:      []string{},
+				InputFile: "testdata/lint/invalid.txt",
+				ErrorText: "4 problem(s) found in /etc/hosts",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - duplicate block id",
+			Args: cmdtest.ITArgs{
+				Args:      []string{},
+				InputFile: "testdata/six-blocks.txt",
+				ErrorText: "4 problem(s) found in /etc/hosts",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - warnings in strict mode",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"--strict"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "1 problem(s) found in /etc/hosts",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - too many arguments",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"/tmp/a", "/tmp/b"},
+				InputFile: "testdata/empty.txt",
+				ErrorText: "too many arguments",
+			},
+			Want: false,
+		},
+		{
+			Name: "error - unsupported output format",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"-o", "xml"},
+				InputFile: "testdata/empty.txt",
+				ErrorText: "value xml is not support; not supported output format",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestLintCommand", func() *cobra.Command { return NewCmdDatabaseLint() })
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/0xcfff/hostsctl/commands/common"
-	"github.com/0xcfff/hostsctl/hosts"
 	"github.com/spf13/cobra"
 )
 
@@ -46,7 +45,7 @@ func (opt *LocationOptions) Validate() error {
 func (opt *LocationOptions) Execute() error {
 
 	out := opt.command.OutOrStdout()
-	fmt.Fprintln(out, hosts.EtcHosts.Path())
+	fmt.Fprintln(out, common.HostsFile(opt.command.Context()))
 
 	return nil
 }
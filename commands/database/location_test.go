@@ -20,6 +20,16 @@ func TestDatabaseLocationCommand(t *testing.T) {
 			},
 			Want: true,
 		},
+		{
+			Name: "location - custom hosts file",
+			Args: cmdtest.ITArgs{
+				Args:      []string{},
+				HostsFile: "/tmp/chroot/etc/hosts",
+				InputFile: "testdata/empty.txt",
+				Stdout:    "/tmp/chroot/etc/hosts\n",
+			},
+			Want: true,
+		},
 		{
 			Name: "location error - too many arguments",
 			Args: cmdtest.ITArgs{
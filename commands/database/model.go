@@ -0,0 +1,74 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/0xcfff/hostsctl/hosts/lint"
+)
+
+type DiffModel struct {
+	From    string         `json:"from"           yaml:"from"`
+	To      string         `json:"to"             yaml:"to"`
+	Changes []*ChangeModel `json:"changes"        yaml:"changes"`
+	Text    string         `json:"text,omitempty" yaml:"text,omitempty"`
+}
+
+type ChangeModel struct {
+	Kind     dom.ChangeKind `json:"kind"               yaml:"kind"`
+	Block    BlockRefModel  `json:"block"              yaml:"block"`
+	OldBlock *BlockRefModel `json:"oldBlock,omitempty" yaml:"oldBlock,omitempty"`
+	IP       string         `json:"ip,omitempty"       yaml:"ip,omitempty"`
+	OldIP    string         `json:"oldIp,omitempty"    yaml:"oldIp,omitempty"`
+	Alias    string         `json:"alias,omitempty"    yaml:"alias,omitempty"`
+}
+
+type BlockRefModel struct {
+	Id   int    `json:"id"             yaml:"id"`
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+func (b BlockRefModel) String() string {
+	if b.Name == "" {
+		return fmt.Sprintf("[%d]", b.Id)
+	}
+	return fmt.Sprintf("[%d] %s", b.Id, b.Name)
+}
+
+func newChangeModel(c *dom.Change) *ChangeModel {
+	m := &ChangeModel{
+		Kind:  c.Kind,
+		Block: BlockRefModel{Id: c.Block.Id, Name: c.Block.Name},
+		IP:    c.IP,
+		OldIP: c.OldIP,
+		Alias: c.Alias,
+	}
+	if c.OldBlock != nil {
+		m.OldBlock = &BlockRefModel{Id: c.OldBlock.Id, Name: c.OldBlock.Name}
+	}
+	return m
+}
+
+type DiagnosticModel struct {
+	File     string        `json:"file"     yaml:"file"`
+	Line     int           `json:"line"     yaml:"line"`
+	Column   int           `json:"column"   yaml:"column"`
+	Severity lint.Severity `json:"severity" yaml:"severity"`
+	Rule     lint.RuleId   `json:"rule"     yaml:"rule"`
+	Message  string        `json:"message"  yaml:"message"`
+}
+
+func NewLintModels(file string, diagnostics []*lint.Diagnostic) []*DiagnosticModel {
+	m := make([]*DiagnosticModel, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		m = append(m, &DiagnosticModel{
+			File:     file,
+			Line:     d.Line,
+			Column:   d.Column,
+			Severity: d.Severity,
+			Rule:     d.Rule,
+			Message:  d.Message,
+		})
+	}
+	return m
+}
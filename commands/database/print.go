@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/0xcfff/hostsctl/commands/common"
-	"github.com/0xcfff/hostsctl/hosts"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
@@ -46,7 +45,7 @@ func (opt *PrintOptions) Validate() error {
 }
 
 func (opt *PrintOptions) Execute() error {
-	src := hosts.NewSource(hosts.EtcHosts.Path(), common.FileSystem(opt.command.Context()))
+	src := common.HostsSource(opt.command.Context())
 
 	out := opt.command.OutOrStdout()
 
@@ -2,18 +2,18 @@ package database
 
 import (
 	"fmt"
-	"io"
-	"os"
 
 	"github.com/0xcfff/hostsctl/commands/common"
 	"github.com/0xcfff/hostsctl/hosts"
-	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
 type RestoreOptions struct {
 	command *cobra.Command
 	source  string
+	id      string
+	latest  bool
+	dryRun  common.DryRunOptions
 }
 
 func NewCmdDatabaseRestore() *cobra.Command {
@@ -23,18 +23,25 @@ func NewCmdDatabaseRestore() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "restore [flags]",
 		Short: "Restore IP aliases database",
+		Long:  "Restore IP aliases database from a backup, the latest backup is used if no backup is specified",
 		Run: func(cmd *cobra.Command, args []string) {
-			cobra.CheckErr(opt.Complete(cmd, args))
-			cobra.CheckErr(opt.Validate())
-			cobra.CheckErr(opt.Execute())
+			common.RunCliCommand(opt, cmd, args)
 		},
 	}
 
-	cmd.Flags().StringVarP(&opt.source, "source", "s", "", "source backup file name")
+	cmd.Flags().StringVarP(&opt.source, "source", "s", "", "Restore from the file instead of the backups directory")
+	cmd.Flags().StringVar(&opt.id, "id", opt.id, "Id of the backup to restore")
+	cmd.Flags().BoolVar(&opt.latest, "latest", opt.latest, "Restore the most recent backup")
+
+	common.AddDryRunFlags(cmd, &opt.dryRun)
 
 	return cmd
 }
 
+func (opt *RestoreOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
 func (opt *RestoreOptions) Complete(cmd *cobra.Command, args []string) error {
 
 	opt.command = cmd
@@ -46,44 +53,51 @@ func (opt *RestoreOptions) Validate() error {
 	if len(args) > 0 {
 		return common.ErrTooManyArguments
 	}
+	cnt := 0
+	for _, set := range []bool{opt.source != "", opt.id != "", opt.latest} {
+		if set {
+			cnt++
+		}
+	}
+	if cnt > 1 {
+		return fmt.Errorf("only one of --source, --id or --latest can be specified; %w", common.ErrWrongArgumentValue)
+	}
 	return nil
 }
 
 func (opt *RestoreOptions) Execute() error {
+	data, err := opt.readBackup()
+	cobra.CheckErr(err)
 
-	sourcePath := opt.source
-	targetPath := hosts.EtcHosts.Path()
-	if sourcePath == "" {
-		sourcePath = fmt.Sprintf("%s.bak", targetPath)
-	}
+	target, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer target.Unlock()
 
-	src := hosts.NewSource(sourcePath, common.FileSystem(opt.command.Context()))
-	err := src.Apply(func(path string, fs afero.Fs) error {
-		sf, err := fs.Open(path)
-		if err != nil {
-			return err
-		}
-		defer sf.Close()
+	err = target.SaveRaw(data)
+	cobra.CheckErr(err)
 
-		tf, err := fs.OpenFile(targetPath, os.O_CREATE|os.O_RDWR, 0o644)
-		if err != nil {
-			return err
-		}
-		defer tf.Close()
+	return nil
+}
 
-		err = tf.Truncate(0)
-		if err != nil {
-			return err
-		}
+func (opt *RestoreOptions) readBackup() ([]byte, error) {
+	ctx := opt.command.Context()
+
+	if opt.source != "" {
+		return hosts.NewSource(opt.source, common.FileSystem(ctx)).LoadRaw()
+	}
 
-		_, err = io.Copy(tf, sf)
+	store := common.BackupStore(ctx)
+	if opt.id != "" {
+		snap, err := store.Get(opt.id)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		return store.Read(snap)
+	}
 
-		return nil
-
-	})
-
-	return err
+	snap, err := store.Latest()
+	if err != nil {
+		return nil, err
+	}
+	return store.Read(snap)
 }
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupFiles = map[string]string{
+		"/etc/hosts.backups/20260101T100000.000000Z.json":  "testdata/backups/20260101T100000.000000Z.json",
+		"/etc/hosts.backups/20260101T100000.000000Z.hosts": "testdata/backups/20260101T100000.000000Z.hosts",
+		"/etc/hosts.backups/20260102T100000.000000Z.json":  "testdata/backups/20260102T100000.000000Z.json",
+		"/etc/hosts.backups/20260102T100000.000000Z.hosts": "testdata/backups/20260102T100000.000000Z.hosts",
+	}
+	corruptedBackupFiles = map[string]string{
+		"/etc/hosts.backups/20260101T100000.000000Z.json":  "testdata/backups/20260101T100000.000000Z.json",
+		"/etc/hosts.backups/20260101T100000.000000Z.hosts": "testdata/backups/corrupted.hosts",
+	}
+)
+
+func TestRestoreCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "restore - latest by default",
+			Args: cmdtest.ITArgs{
+				Args:       []string{},
+				InputFile:  "testdata/empty.txt",
+				ExtraFiles: backupFiles,
+				OutputFile: "testdata/six-blocks.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "restore - latest",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--latest"},
+				InputFile:  "testdata/empty.txt",
+				ExtraFiles: backupFiles,
+				OutputFile: "testdata/six-blocks.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "restore - by id",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--id", "20260101T100000.000000Z"},
+				InputFile:  "testdata/empty.txt",
+				ExtraFiles: backupFiles,
+				OutputFile: "testdata/four-blocks.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "restore - from file",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-s", "/tmp/hosts.copy"},
+				InputFile:  "testdata/empty.txt",
+				ExtraFiles: map[string]string{"/tmp/hosts.copy": "testdata/five-blocks.txt"},
+				OutputFile: "testdata/five-blocks.txt",
+			},
+			Want: true,
+		},
+
+		// errors cases
+		{
+			Name: "restore error - no backups",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"--latest"},
+				InputFile: "testdata/empty.txt",
+				ErrorText: "no backups found",
+			},
+			Want: false,
+		},
+		{
+			Name: "restore error - id not found",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--id", "20250101T100000.000000Z"},
+				InputFile:  "testdata/empty.txt",
+				ExtraFiles: backupFiles,
+				ErrorText:  "id: 20250101T100000.000000Z; backup not found",
+			},
+			Want: false,
+		},
+		{
+			Name: "restore error - checksum mismatch",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--latest"},
+				InputFile:  "testdata/empty.txt",
+				ExtraFiles: corruptedBackupFiles,
+				ErrorText:  "backup checksum mismatch",
+			},
+			Want: false,
+		},
+		{
+			Name: "restore error - id and latest",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--latest", "--id", "20260101T100000.000000Z"},
+				InputFile:  "testdata/empty.txt",
+				ExtraFiles: backupFiles,
+				ErrorText:  "only one of --source, --id or --latest can be specified; wrong argument value",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestRestoreCommand", func() *cobra.Command { return NewCmdDatabaseRestore() })
+}
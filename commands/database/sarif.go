@@ -0,0 +1,112 @@
+package database
+
+import (
+	"github.com/0xcfff/hostsctl/hosts/lint"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	sarifTool    = "hostsctl"
+	sarifToolUri = "https://github.com/0xcfff/hostsctl"
+)
+
+// Static Analysis Results Interchange Format (SARIF) log, only the properties used by lint are defined
+type SarifLog struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []*SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool      `json:"tool"`
+	Results []*SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string       `json:"name"`
+	InformationUri string       `json:"informationUri"`
+	Rules          []*SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	Id                   string             `json:"id"`
+	ShortDescription     SarifMessage       `json:"shortDescription"`
+	DefaultConfiguration SarifConfiguration `json:"defaultConfiguration"`
+}
+
+type SarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifResult struct {
+	RuleId    string           `json:"ruleId"`
+	Level     string           `json:"level"`
+	Message   SarifMessage     `json:"message"`
+	Locations []*SarifLocation `json:"locations"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           SarifRegion           `json:"region"`
+}
+
+type SarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+type SarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+func NewSarifLog(diagnostics []*DiagnosticModel) *SarifLog {
+	rules := make([]*SarifRule, 0, len(lint.Rules))
+	for _, r := range lint.Rules {
+		rules = append(rules, &SarifRule{
+			Id:                   string(r.Id),
+			ShortDescription:     SarifMessage{Text: r.Description},
+			DefaultConfiguration: SarifConfiguration{Level: string(r.Severity)},
+		})
+	}
+
+	results := make([]*SarifResult, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		results = append(results, &SarifResult{
+			RuleId:  string(d.Rule),
+			Level:   string(d.Severity),
+			Message: SarifMessage{Text: d.Message},
+			Locations: []*SarifLocation{{
+				PhysicalLocation: SarifPhysicalLocation{
+					ArtifactLocation: SarifArtifactLocation{Uri: d.File},
+					Region:           SarifRegion{StartLine: d.Line, StartColumn: d.Column},
+				},
+			}},
+		})
+	}
+
+	return &SarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []*SarifRun{{
+			Tool: SarifTool{Driver: SarifDriver{
+				Name:           sarifTool,
+				InformationUri: sarifToolUri,
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+}
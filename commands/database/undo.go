@@ -0,0 +1,124 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts/journal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	errChangedOutside = errors.New("hosts file was changed outside of hostsctl, use --force to overwrite the changes")
+)
+
+type UndoOptions struct {
+	command *cobra.Command
+	undo    bool
+	force   bool
+}
+
+func NewCmdDatabaseUndo() *cobra.Command {
+	return newCmdDatabaseUndoRedo(true)
+}
+
+func NewCmdDatabaseRedo() *cobra.Command {
+	return newCmdDatabaseUndoRedo(false)
+}
+
+func newCmdDatabaseUndoRedo(undo bool) *cobra.Command {
+
+	opt := &UndoOptions{
+		undo: undo,
+	}
+
+	use := "redo"
+	short := "Reapplies the most recently undone IP aliases database change"
+	if undo {
+		use = "undo"
+		short = "Reverts the most recent IP aliases database change"
+	}
+
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s [flags]", use),
+		Short: short,
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(opt.Complete(cmd, args))
+			cobra.CheckErr(opt.Validate())
+			cobra.CheckErr(opt.Execute())
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Overwrite changes made to the hosts file outside of hostsctl")
+
+	return cmd
+}
+
+func (opt *UndoOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+	return nil
+}
+
+func (opt *UndoOptions) Validate() error {
+	args := opt.command.Flags().Args()
+	if len(args) > 0 {
+		return common.ErrTooManyArguments
+	}
+	return nil
+}
+
+func (opt *UndoOptions) Execute() error {
+	ctx := opt.command.Context()
+
+	src, err := common.LockedHostsSource(ctx)
+	cobra.CheckErr(err)
+	defer src.Unlock()
+	// undo and redo move along the journal instead of adding new changes to it
+	src.SetSaveHook(nil)
+
+	jrn := common.Journal(ctx)
+
+	var entry *journal.Entry
+	if opt.undo {
+		entry, err = jrn.Undoable()
+	} else {
+		entry, err = jrn.Redoable()
+	}
+	cobra.CheckErr(err)
+
+	current, err := src.LoadRaw()
+	cobra.CheckErr(err)
+
+	var data []byte
+	var state journal.State
+	if opt.undo {
+		if !entry.IsAfter(current) && !opt.force {
+			cobra.CheckErr(errChangedOutside)
+		}
+		data, err = jrn.ContentBefore(entry)
+		state = journal.Undone
+	} else {
+		if !entry.IsBefore(current) && !opt.force {
+			cobra.CheckErr(errChangedOutside)
+		}
+		data, err = jrn.ContentAfter(entry)
+		state = journal.Applied
+	}
+	cobra.CheckErr(err)
+
+	err = src.SaveRaw(data)
+	cobra.CheckErr(err)
+
+	err = jrn.SetState(entry, state)
+	cobra.CheckErr(err)
+
+	verb := "Redone"
+	if opt.undo {
+		verb = "Undone"
+	}
+	fmt.Fprintf(opt.command.OutOrStdout(), "%s %d: %s\n", verb, entry.Seq, entry.Command)
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+var (
+	journalFiles = map[string]string{
+		"/etc/hosts.backups/journal/index.json": "testdata/journal/index.json",
+		"/etc/hosts.backups/journal/1.before":   "testdata/journal/1.before",
+		"/etc/hosts.backups/journal/1.after":    "testdata/journal/1.after",
+		"/etc/hosts.backups/journal/2.before":   "testdata/journal/2.before",
+		"/etc/hosts.backups/journal/2.after":    "testdata/journal/2.after",
+	}
+)
+
+func TestUndoCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "undo - last applied change",
+			Args: cmdtest.ITArgs{
+				Args:       []string{},
+				InputFile:  "testdata/four-blocks.txt",
+				ExtraFiles: journalFiles,
+				OutputFile: "testdata/empty.txt",
+				Stdout:     "Undone 1: hostsctl block add --name pet-prj1\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "undo - changed outside, forced",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--force"},
+				InputFile:  "testdata/six-blocks.txt",
+				ExtraFiles: journalFiles,
+				OutputFile: "testdata/empty.txt",
+				Stdout:     "Undone 1: hostsctl block add --name pet-prj1\n",
+			},
+			Want: true,
+		},
+
+		// errors cases
+		{
+			Name: "undo error - changed outside",
+			Args: cmdtest.ITArgs{
+				Args:       []string{},
+				InputFile:  "testdata/six-blocks.txt",
+				ExtraFiles: journalFiles,
+				ErrorText:  "hosts file was changed outside of hostsctl, use --force to overwrite the changes",
+			},
+			Want: false,
+		},
+		{
+			Name: "undo error - empty journal",
+			Args: cmdtest.ITArgs{
+				Args:      []string{},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "nothing to undo",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestUndoCommand", func() *cobra.Command { return NewCmdDatabaseUndo() })
+}
+
+func TestRedoCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "redo - first undone change",
+			Args: cmdtest.ITArgs{
+				Args:       []string{},
+				InputFile:  "testdata/four-blocks.txt",
+				ExtraFiles: journalFiles,
+				OutputFile: "testdata/five-blocks.txt",
+				Stdout:     "Redone 2: hostsctl alias add 192.168.100.101 cats.example.org\n",
+			},
+			Want: true,
+		},
+
+		// errors cases
+		{
+			Name: "redo error - empty journal",
+			Args: cmdtest.ITArgs{
+				Args:      []string{},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "nothing to redo",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestRedoCommand", func() *cobra.Command { return NewCmdDatabaseRedo() })
+}
+
+func TestHistoryCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "history - empty",
+			Args: cmdtest.ITArgs{
+				Args:      []string{},
+				InputFile: "testdata/four-blocks.txt",
+				Stdout:    "SEQ  TIME  STATE  COMMAND\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "history json - two changes",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-o", "json"},
+				InputFile:  "testdata/four-blocks.txt",
+				ExtraFiles: journalFiles,
+				StdoutFile: "testdata/history/history_json__two_changes__output.txt",
+			},
+			Want: true,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestHistoryCommand", func() *cobra.Command { return NewCmdDatabaseHistory() })
+}
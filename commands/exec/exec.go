@@ -0,0 +1,283 @@
+package exec
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/0xcfff/hostsctl/iptools"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+)
+
+const defaultExecBlock = "tmp"
+
+type ExecOptions struct {
+	command       *cobra.Command
+	aliasValues   []string
+	blockIdOrName string
+	force         bool
+	aliases       []*dom.IPAliasesEntry
+	childArgs     []string
+}
+
+// Aliases added by exec, they are removed when the child process exits
+type execAdded struct {
+	blockCreated bool
+	entries      []*dom.IPAliasesEntry
+}
+
+func NewCmdExec() *cobra.Command {
+
+	opt := &ExecOptions{
+		blockIdOrName: defaultExecBlock,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "exec --alias ip=alias[,alias] [--block block] -- command [args...]",
+		Short: "Runs a command with temporary IP aliases",
+		Long: fmt.Sprintf(`Runs a command with temporary IP aliases added to %s file.
+
+The aliases are added to the block before the command is started and exactly the added
+aliases are removed when the command exits or hostsctl receives SIGINT or SIGTERM,
+the block is deleted if it was created for the command and nothing else was added to it.
+Changes made to other blocks meanwhile are kept. hostsctl exits with the exit code of the command.`, hosts.EtcHosts.Path()),
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.RunCliCommand(opt, cmd, args)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opt.aliasValues, "alias", "a", opt.aliasValues, "IP alias to add as ip=alias[,alias], can be repeated")
+	cmd.Flags().StringVarP(&opt.blockIdOrName, "block", "b", opt.blockIdOrName, "Block id or name, the block is created if it is missing")
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Add aliases to the block even if it is locked")
+	// everything after the command name belongs to the command
+	cmd.Flags().SetInterspersed(false)
+
+	return cmd
+}
+
+func (opt *ExecOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+	opt.childArgs = cmd.Flags().Args()
+
+	for _, v := range opt.aliasValues {
+		entry, err := parseExecAlias(v)
+		if err != nil {
+			return err
+		}
+		opt.aliases = append(opt.aliases, entry)
+	}
+
+	return nil
+}
+
+func (opt *ExecOptions) Validate() error {
+	if len(opt.aliases) == 0 {
+		return fmt.Errorf("at least one --alias expected; %w", common.ErrNotEnoughArguments)
+	}
+	if len(opt.childArgs) == 0 {
+		return fmt.Errorf("command to run expected; %w", common.ErrNotEnoughArguments)
+	}
+	if opt.blockIdOrName == "" {
+		return fmt.Errorf("--block can't be empty; %w", common.ErrWrongArgumentValue)
+	}
+	return nil
+}
+
+func (opt *ExecOptions) Execute() error {
+	// signals are caught before the aliases are added, so they can't be left behind
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	added, err := opt.addAliases()
+	cobra.CheckErr(err)
+
+	exitCode, runErr := opt.runChild(signals)
+
+	err = opt.removeAliases(added)
+	cobra.CheckErr(err)
+
+	cobra.CheckErr(runErr)
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+	return nil
+}
+
+func (opt *ExecOptions) addAliases() (*execAdded, error) {
+	src, err := common.LockedHostsSource(opt.command.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer src.Unlock()
+
+	doc, err := src.Load()
+	if err != nil {
+		return nil, err
+	}
+	locked := common.FindLockedBlocks(doc)
+
+	added := &execAdded{
+		blockCreated: doc.IPsBlockByIdOrName(opt.blockIdOrName) == nil,
+	}
+	block, err := common.FindOrCreateTargetAliasesBlock(doc, opt.blockIdOrName, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range opt.aliases {
+		block.AddEntry(a)
+		added.entries = append(added.entries, a)
+	}
+
+	err = common.CheckLockedBlocks(doc, locked, opt.force)
+	if err != nil {
+		return nil, err
+	}
+
+	doc.Normalize()
+
+	err = src.Save(doc, dom.FmtKeep)
+	if err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+// Runs the child process forwarding the signals to it, returns its exit code
+func (opt *ExecOptions) runChild(signals chan os.Signal) (int, error) {
+	child := osexec.Command(opt.childArgs[0], opt.childArgs[1:]...)
+	child.Stdin = opt.command.InOrStdin()
+	child.Stdout = opt.command.OutOrStdout()
+	child.Stderr = opt.command.ErrOrStderr()
+
+	if err := child.Start(); err != nil {
+		return 0, fmt.Errorf("can't run %s; %w", opt.childArgs[0], err)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				// the process may have already exited, nothing to do then
+				_ = child.Process.Signal(sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	err := child.Wait()
+	var exitErr *osexec.ExitError
+	if errors.As(err, &exitErr) {
+		code := exitErr.ExitCode()
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			// shell convention for processes killed by a signal
+			code = 128 + int(status.Signal())
+		} else if code < 0 {
+			code = 1
+		}
+		return code, nil
+	}
+	return 0, err
+}
+
+// Removes the aliases added by exec from the block as it is now in the hosts file,
+// entries changed meanwhile lose only the added aliases
+func (opt *ExecOptions) removeAliases(added *execAdded) error {
+	src, err := common.LockedHostsSource(opt.command.Context())
+	if err != nil {
+		return err
+	}
+	defer src.Unlock()
+
+	doc, err := src.Load()
+	if err != nil {
+		return err
+	}
+
+	block := doc.IPsBlockByIdOrName(opt.blockIdOrName)
+	if block == nil {
+		return nil
+	}
+	for _, a := range added.entries {
+		removeAddedEntry(block, a)
+	}
+	if added.blockCreated && len(block.AliasEntries()) == 0 {
+		common.DeleteBlocks(doc, []*dom.IPAliasesBlock{block})
+	}
+
+	doc.Normalize()
+
+	return src.Save(doc, dom.FmtKeep)
+}
+
+// Removes the entry added by exec, it is looked up from the end of the block as entries
+// are appended there, so the same aliases defined earlier in the block are kept
+func removeAddedEntry(block *dom.IPAliasesBlock, added *dom.IPAliasesEntry) {
+	var found *dom.IPAliasesEntry
+	entries := block.AliasEntriesByIP(added.IP())
+	for i := len(entries) - 1; i >= 0; i-- {
+		ent := entries[i]
+		if slices.Equal(ent.Aliases(), added.Aliases()) {
+			found = ent
+			break
+		}
+		if found == nil && containsAll(ent.Aliases(), added.Aliases()) {
+			found = ent
+		}
+	}
+	if found == nil {
+		return
+	}
+	if slices.Equal(found.Aliases(), added.Aliases()) {
+		block.RemoveEntry(found)
+		return
+	}
+	for _, a := range added.Aliases() {
+		found.RemoveAlias(a)
+	}
+}
+
+func containsAll(values []string, subset []string) bool {
+	for _, v := range subset {
+		if !slices.Contains(values, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Parses ip=alias[,alias] value of --alias flag
+func parseExecAlias(value string) (*dom.IPAliasesEntry, error) {
+	ip, names, found := strings.Cut(value, "=")
+	ip = strings.TrimSpace(ip)
+	if !found || !iptools.IsIP(ip) {
+		return nil, fmt.Errorf("--alias %s is not in ip=alias[,alias] format; %w", value, common.ErrWrongArgumentValue)
+	}
+	aliases := make([]string, 0)
+	for _, name := range strings.FieldsFunc(names, func(r rune) bool { return r == ',' || r == ' ' }) {
+		ascii, err := iptools.ToASCIIHostname(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid alias; %w", name, common.ErrWrongArgumentValue)
+		}
+		aliases = append(aliases, ascii)
+	}
+	if len(aliases) == 0 {
+		return nil, fmt.Errorf("--alias %s has no aliases; %w", value, common.ErrWrongArgumentValue)
+	}
+	entry := dom.NewIPAliasesEntry(ip)
+	entry.SetAliases(aliases)
+	return entry, nil
+}
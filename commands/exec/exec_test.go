@@ -0,0 +1,147 @@
+package exec
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+// Sends the signal given as $1 to hostsctl and exits successfully only if the signal is
+// forwarded back, the sleep is detached from the output so it doesn't hold the test
+const execSignalScript = `trap 'exit 0' $1; kill -$1 $PPID; sleep 5 >/dev/null 2>&1 </dev/null & wait; exit 1`
+
+func TestExecCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "exec - new block removed",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-a", "10.0.0.1=api.local,db.local", "--", "echo", "hello"},
+				Stdin:      "",
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/four-blocks.txt",
+				Stdout:     "hello\n",
+				ErrorText:  "",
+			},
+			Want: true,
+		},
+		{
+			Name: "exec - existing block restored",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-a", "192.168.100.101=dogs.example.org", "-a", "10.0.0.1=api.local", "-b", "pet-prj1", "echo", "hello"},
+				Stdin:      "",
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/four-blocks.txt",
+				Stdout:     "hello\n",
+				ErrorText:  "",
+			},
+			Want: true,
+		},
+		{
+			Name: "exec - command flags not parsed",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-a", "10.0.0.1=api.local", "echo", "-b", "x"},
+				Stdin:      "",
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/four-blocks.txt",
+				Stdout:     "-b x\n",
+				ErrorText:  "",
+			},
+			Want: true,
+		},
+		{
+			Name: "exec - alias already in block kept",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-a", "10.0.0.1=api.local", "-b", "dev", "echo", "hello"},
+				Stdin:      "",
+				InputFile:  "testdata/dev-block.txt",
+				OutputFile: "testdata/dev-block.txt",
+				Stdout:     "hello\n",
+				ErrorText:  "",
+			},
+			Want: true,
+		},
+		{
+			Name: "exec - aliases removed on SIGTERM",
+			Args: cmdtest.ITArgs{
+				// the command signals hostsctl, which forwards the signal back to the command
+				Args:       []string{"-a", "10.0.0.1=api.local", "--", "sh", "-c", execSignalScript, "sh", "TERM"},
+				Stdin:      "",
+				InputFile:  "testdata/four-blocks.txt",
+				OutputFile: "testdata/four-blocks.txt",
+				Stdout:     "",
+				ErrorText:  "",
+			},
+			Want: true,
+		},
+		{
+			Name: "exec - aliases removed on SIGINT",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-a", "10.0.0.1=api.local", "-b", "dev", "--", "sh", "-c", execSignalScript, "sh", "INT"},
+				Stdin:      "",
+				InputFile:  "testdata/dev-block.txt",
+				OutputFile: "testdata/dev-block.txt",
+				Stdout:     "",
+				ErrorText:  "",
+			},
+			Want: true,
+		},
+		{
+			Name: "exec error - command exit code",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"-a", "10.0.0.1=api.local", "--", "sh", "-c", "exit 3"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "",
+				ExitCode:  3,
+			},
+			Want: false,
+		},
+		{
+			Name: "exec error - command not found",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"-a", "10.0.0.1=api.local", "--", "hostsctl-missing-command"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "can't run hostsctl-missing-command",
+			},
+			Want: false,
+		},
+		{
+			Name: "exec error - no command",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"-a", "10.0.0.1=api.local"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "command to run expected",
+			},
+			Want: false,
+		},
+		{
+			Name: "exec error - no aliases",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"echo", "hello"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "at least one --alias expected",
+			},
+			Want: false,
+		},
+		{
+			Name: "exec error - wrong alias format",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"-a", "api.local", "echo", "hello"},
+				InputFile: "testdata/four-blocks.txt",
+				ErrorText: "--alias api.local is not in ip=alias[,alias] format",
+			},
+			Want: false,
+		},
+		{
+			Name: "exec error - locked block",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"-a", "10.0.0.1=api.local", "-b", "corp-vpn", "echo", "hello"},
+				InputFile: "testdata/locked-blocks.txt",
+				ErrorText: "block [3] corp-vpn is locked, use --force to change it",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestExecCommand", func() *cobra.Command { return NewCmdExec() })
+}
@@ -0,0 +1,132 @@
+package gc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+)
+
+type GcOptions struct {
+	command *cobra.Command
+	disable bool
+	force   bool
+	dryRun  common.DryRunOptions
+}
+
+func NewCmdGc() *cobra.Command {
+
+	opt := &GcOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: fmt.Sprintf("Removes expired IP aliases from %s file", hosts.EtcHosts.Path()),
+		Long: fmt.Sprintf(`Removes expired IP aliases from %s file.
+
+An entry is expired if its comment has "@expires: <time>" in the past, such entries are
+created by 'alias add --ttl' or 'alias add --expires'. The command is suitable to be run
+by cron or a systemd timer, it prints nothing if no entries are expired.
+Expired entries of locked blocks are reported as skipped and kept unless --force is specified.`, hosts.EtcHosts.Path()),
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.RunCliCommand(opt, cmd, args)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opt.disable, "disable", opt.disable, "Comment out expired entries instead of removing them")
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Remove expired entries of locked blocks too")
+
+	common.AddDryRunFlags(cmd, &opt.dryRun)
+
+	return cmd
+}
+
+func (opt *GcOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
+func (opt *GcOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+	return nil
+}
+
+func (opt *GcOptions) Validate() error {
+	args := opt.command.Flags().Args()
+	if len(args) > 0 {
+		return common.ErrTooManyArguments
+	}
+	return nil
+}
+
+func (opt *GcOptions) Execute() error {
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
+	doc, err := src.Load()
+	cobra.CheckErr(err)
+	locked := common.FindLockedBlocks(doc)
+
+	now := common.Now(opt.command.Context())
+	results := make([]string, 0)
+	changed := 0
+	for _, block := range doc.IPBlocks() {
+		// one locked block must not stop collecting expired entries of the others
+		lockedIdx := slices.IndexFunc(locked, func(lb common.LockedBlock) bool { return lb.Block == block })
+		for _, ent := range block.AliasEntries() {
+			expires, ok := ent.Expires()
+			if !ok || expires.After(now) || opt.disable && ent.Disabled() {
+				continue
+			}
+			expired := fmt.Sprintf("%s %s, expired %s", ent.IP(), strings.Join(ent.Aliases(), " "), expires.UTC().Format(time.RFC3339))
+			if lockedIdx >= 0 && !opt.force {
+				lb := locked[lockedIdx]
+				by := ""
+				if lb.LockedBy != "" {
+					by = " by " + lb.LockedBy
+				}
+				results = append(results, fmt.Sprintf("skipped %s, block %s is locked%s", expired, lb.Title, by))
+				continue
+			}
+			action := "removed"
+			if opt.disable {
+				ent.SetDisabled(true)
+				action = "disabled"
+			} else {
+				block.RemoveEntry(ent)
+			}
+			results = append(results, fmt.Sprintf("%s %s", action, expired))
+			changed++
+		}
+	}
+
+	// gc is run by cron, the hosts file is not rewritten if nothing is expired
+	if changed == 0 {
+		opt.printResults(results)
+		return nil
+	}
+
+	err = common.CheckLockedBlocks(doc, locked, opt.force)
+	cobra.CheckErr(err)
+
+	doc.Normalize()
+
+	err = src.Save(doc, dom.FmtKeep)
+	cobra.CheckErr(err)
+
+	opt.printResults(results)
+
+	return nil
+}
+
+func (opt *GcOptions) printResults(results []string) {
+	for _, r := range results {
+		fmt.Fprintln(opt.command.OutOrStdout(), r)
+	}
+}
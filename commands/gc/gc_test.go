@@ -0,0 +1,92 @@
+package gc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestGcCommand(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tests := []cmdtest.ITTest{
+		{
+			Name: "gc - remove expired",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--force"},
+				InputFile:  "testdata/expiring-aliases.txt",
+				OutputFile: "testdata/gc/gc_forced__expiring_aliases__result.txt",
+				Stdout:     "removed 10.0.0.5 demo.local, expired 2026-10-18T08:00:00Z\nremoved 10.0.0.7 old.demo.local, expired 2026-10-01T00:00:00Z\nremoved 10.8.0.1 vpn.corp.example.com, expired 2026-10-10T00:00:00Z\n",
+				Now:        now,
+			},
+			Want: true,
+		},
+		{
+			Name: "gc - disable expired",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--disable", "--force"},
+				InputFile:  "testdata/expiring-aliases.txt",
+				OutputFile: "testdata/gc/gc_disable_forced__expiring_aliases__result.txt",
+				Stdout:     "disabled 10.0.0.5 demo.local, expired 2026-10-18T08:00:00Z\ndisabled 10.8.0.1 vpn.corp.example.com, expired 2026-10-10T00:00:00Z\n",
+				Now:        now,
+			},
+			Want: true,
+		},
+		{
+			Name: "gc - nothing expired",
+			Args: cmdtest.ITArgs{
+				Args:       []string{},
+				InputFile:  "testdata/expiring-aliases.txt",
+				OutputFile: "testdata/expiring-aliases.txt",
+				Stdout:     "",
+				Now:        time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+			},
+			Want: true,
+		},
+		{
+			Name: "gc - dry run",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"--dry-run", "--force"},
+				InputFile:  "testdata/expiring-aliases.txt",
+				OutputFile: "testdata/expiring-aliases.txt",
+				StdoutFile: "testdata/gc/gc_dry_run__expiring_aliases__output.txt",
+				Now:        now,
+			},
+			Want: true,
+		},
+		{
+			Name: "gc - locked block skipped",
+			Args: cmdtest.ITArgs{
+				Args:       []string{},
+				InputFile:  "testdata/expiring-aliases.txt",
+				OutputFile: "testdata/gc/gc__expiring_aliases__result.txt",
+				Stdout:     "removed 10.0.0.5 demo.local, expired 2026-10-18T08:00:00Z\nremoved 10.0.0.7 old.demo.local, expired 2026-10-01T00:00:00Z\nskipped 10.8.0.1 vpn.corp.example.com, expired 2026-10-10T00:00:00Z, block [16] vpn is locked by ansible\n",
+				Now:        now,
+			},
+			Want: true,
+		},
+		{
+			Name: "gc - only locked block expired",
+			Args: cmdtest.ITArgs{
+				Args:       []string{},
+				InputFile:  "testdata/gc/gc__expiring_aliases__result.txt",
+				OutputFile: "testdata/gc/gc__expiring_aliases__result.txt",
+				Stdout:     "skipped 10.8.0.1 vpn.corp.example.com, expired 2026-10-10T00:00:00Z, block [16] vpn is locked by ansible\n",
+				Now:        now,
+			},
+			Want: true,
+		},
+		{
+			Name: "gc error - too many arguments",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"demo"},
+				InputFile: "testdata/expiring-aliases.txt",
+				ErrorText: "too many arguments",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestGcCommand", func() *cobra.Command { return NewCmdGc() })
+}
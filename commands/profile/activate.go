@@ -0,0 +1,119 @@
+package profile
+
+import (
+	"fmt"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/spf13/cobra"
+)
+
+type ProfileActivateOptions struct {
+	command    *cobra.Command
+	name       string
+	deactivate bool
+	force      bool
+	dryRun     common.DryRunOptions
+}
+
+func NewCmdProfileActivate() *cobra.Command {
+	return newCmdProfileActivate(false)
+}
+
+func NewCmdProfileDeactivate() *cobra.Command {
+	return newCmdProfileActivate(true)
+}
+
+func newCmdProfileActivate(deactivate bool) *cobra.Command {
+
+	opt := &ProfileActivateOptions{
+		deactivate: deactivate,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "activate <name>",
+		Short: fmt.Sprintf("Activates profile by enabling its IP aliases in %s file", hosts.EtcHosts.Path()),
+		Long: fmt.Sprintf(`Activates profile by enabling its IP aliases in %s file.
+
+Other profiles of the same group are deactivated, their IP aliases are commented out.`, hosts.EtcHosts.Path()),
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.RunCliCommand(opt, cmd, args)
+		},
+	}
+	if deactivate {
+		cmd.Use = "deactivate <name>"
+		cmd.Short = fmt.Sprintf("Deactivates profile by commenting out its IP aliases in %s file", hosts.EtcHosts.Path())
+		cmd.Long = ""
+	}
+
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Force command to succeed even if profile contains system aliases or locked blocks")
+
+	common.AddDryRunFlags(cmd, &opt.dryRun)
+
+	return cmd
+}
+
+func (opt *ProfileActivateOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
+func (opt *ProfileActivateOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	parsedArgs := cmd.Flags().Args()
+	if len(parsedArgs) > 0 {
+		opt.name = parsedArgs[0]
+	}
+
+	return nil
+}
+
+func (opt *ProfileActivateOptions) Validate() error {
+	args := opt.command.Flags().Args()
+	if len(args) > 1 {
+		return common.ErrTooManyArguments
+	}
+	if len(args) < 1 {
+		return fmt.Errorf("profile name expected; %w", common.ErrNotEnoughArguments)
+	}
+	return nil
+}
+
+func (opt *ProfileActivateOptions) Execute() error {
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
+	doc, err := src.Load()
+	cobra.CheckErr(err)
+	locked := common.FindLockedBlocks(doc)
+
+	profiles := findProfiles(doc)
+	target, err := findProfile(profiles, opt.name)
+	cobra.CheckErr(err)
+
+	system := common.SystemAliases(opt.command.Context())
+	if !opt.deactivate && target.group != "" {
+		for _, p := range profiles {
+			if p != target && p.group == target.group {
+				err = p.toggle(true, system, opt.force)
+				cobra.CheckErr(err)
+			}
+		}
+	}
+	err = target.toggle(opt.deactivate, system, opt.force)
+	cobra.CheckErr(err)
+
+	err = common.CheckLockedBlocks(doc, locked, opt.force)
+	cobra.CheckErr(err)
+
+	doc.Normalize()
+
+	err = src.Save(doc, dom.FmtKeep)
+	cobra.CheckErr(err)
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestProfileActivateCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "activate - siblings deactivated",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"staging-tunnel"},
+				InputFile:  "testdata/profiles.txt",
+				OutputFile: "testdata/activate/activate__siblings_deactivated__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "activate - no group",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"tools"},
+				InputFile:  "testdata/profiles.txt",
+				OutputFile: "testdata/activate/activate__no_group__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "activate - partial sibling deactivated",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"local"},
+				InputFile:  "testdata/profiles.txt",
+				OutputFile: "testdata/activate/activate__partial_sibling__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "activate - active profile, dry run, exit code, no changes",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"local", "--dry-run", "--exit-code"},
+				InputFile:  "testdata/activate/activate__partial_sibling__result.txt",
+				OutputFile: "testdata/activate/activate__partial_sibling__result.txt",
+				Stdout:     "",
+			},
+			Want: true,
+		},
+		{
+			Name: "activate - locked sibling, forced",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"vpn", "--force"},
+				InputFile:  "testdata/profiles.txt",
+				OutputFile: "testdata/activate/activate_forced__locked_sibling__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "activate error - locked profile block",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"vpn"},
+				InputFile: "testdata/profiles.txt",
+				ErrorText: "block [6] corp-vpn is locked by ansible, use --force to change it",
+			},
+			Want: false,
+		},
+		{
+			Name: "activate error - profile not found",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"missing"},
+				InputFile: "testdata/profiles.txt",
+				ErrorText: "profile missing; profile not found",
+			},
+			Want: false,
+		},
+		{
+			Name: "activate error - no name",
+			Args: cmdtest.ITArgs{
+				Args:      []string{},
+				InputFile: "testdata/profiles.txt",
+				ErrorText: "profile name expected",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestProfileActivateCommand", func() *cobra.Command { return NewCmdProfileActivate() })
+}
+
+func TestProfileDeactivateCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "deactivate - siblings unchanged",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"local"},
+				InputFile:  "testdata/profiles.txt",
+				OutputFile: "testdata/activate/deactivate__siblings_unchanged__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "deactivate - system aliases, forced",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"sys", "-f"},
+				InputFile:  "testdata/system-profile.txt",
+				OutputFile: "testdata/activate/deactivate_forced__system_profile__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "deactivate error - system aliases",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"sys"},
+				InputFile: "testdata/system-profile.txt",
+				ErrorText: "profile sys contains system alias localhost, use --force to disable it",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestProfileDeactivateCommand", func() *cobra.Command { return NewCmdProfileDeactivate() })
+}
@@ -0,0 +1,159 @@
+package profile
+
+import (
+	"fmt"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/spf13/cobra"
+)
+
+type ProfileCreateOptions struct {
+	command *cobra.Command
+	name    string
+	blocks  []string
+	group   string
+	comment string
+	force   bool
+	dryRun  common.DryRunOptions
+}
+
+func NewCmdProfileCreate() *cobra.Command {
+
+	opt := &ProfileCreateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create <name> [--block id or name]... [--group group]",
+		Short: fmt.Sprintf("Creates profile from IP aliases blocks of %s file", hosts.EtcHosts.Path()),
+		Long: fmt.Sprintf(`Creates profile from IP aliases blocks of %s file.
+
+The blocks are labeled with the profile name, a block named as the profile is used
+(and created if missing) if no --block is specified.`, hosts.EtcHosts.Path()),
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.RunCliCommand(opt, cmd, args)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opt.blocks, "block", "b", opt.blocks, "Block id or name to include into the profile, can be repeated")
+	cmd.Flags().StringVarP(&opt.group, "group", "g", opt.group, "Group of mutually exclusive profiles the profile belongs to")
+	cmd.Flags().StringVarP(&opt.comment, "comment", "c", opt.comment, "Comment of the block created for the profile")
+	cmd.Flags().BoolVarP(&opt.force, "force", "f", opt.force, "Label the blocks even if they are locked")
+
+	common.AddDryRunFlags(cmd, &opt.dryRun)
+
+	return cmd
+}
+
+func (opt *ProfileCreateOptions) DryRun() *common.DryRunOptions {
+	return &opt.dryRun
+}
+
+func (opt *ProfileCreateOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	parsedArgs := cmd.Flags().Args()
+	if len(parsedArgs) > 0 {
+		opt.name = parsedArgs[0]
+	}
+
+	return nil
+}
+
+func (opt *ProfileCreateOptions) Validate() error {
+	args := opt.command.Flags().Args()
+	if len(args) > 1 {
+		return common.ErrTooManyArguments
+	}
+	if len(args) < 1 {
+		return fmt.Errorf("profile name expected; %w", common.ErrNotEnoughArguments)
+	}
+	if err := validateName("profile", opt.name); err != nil {
+		return err
+	}
+	if opt.group != "" {
+		if err := validateName("group", opt.group); err != nil {
+			return err
+		}
+	}
+	if opt.comment != "" && len(opt.blocks) > 0 {
+		return fmt.Errorf("--comment can't be used with --block; %w", common.ErrWrongArgumentValue)
+	}
+	return nil
+}
+
+func (opt *ProfileCreateOptions) Execute() error {
+	src, err := common.LockedHostsSource(opt.command.Context())
+	cobra.CheckErr(err)
+	defer src.Unlock()
+
+	doc, err := src.Load()
+	cobra.CheckErr(err)
+	locked := common.FindLockedBlocks(doc)
+
+	if _, err := findProfile(findProfiles(doc), opt.name); err == nil {
+		return fmt.Errorf("profile %s; %w", opt.name, common.ErrEntryAlreadyExists)
+	}
+
+	blocks, err := findProfileBlocks(doc, opt)
+	cobra.CheckErr(err)
+
+	for _, block := range blocks {
+		block.SetLabel(labelProfile, opt.name)
+		if opt.group != "" {
+			block.SetLabel(labelGroup, opt.group)
+		} else {
+			block.RemoveLabel(labelGroup)
+		}
+	}
+
+	err = common.CheckLockedBlocks(doc, locked, opt.force)
+	cobra.CheckErr(err)
+
+	doc.Normalize()
+
+	err = src.Save(doc, dom.FmtKeep)
+	cobra.CheckErr(err)
+
+	return nil
+}
+
+// Returns blocks specified by --block flags or the block named as the profile,
+// the latter is created if it does not exist
+func findProfileBlocks(doc *dom.Document, opt *ProfileCreateOptions) ([]*dom.IPAliasesBlock, error) {
+	if len(opt.blocks) == 0 {
+		block := doc.IPsBlockByName(opt.name)
+		if block == nil {
+			block = dom.NewIPAliasesBlock()
+			block.SetName(opt.name)
+			block.SetNote(opt.comment)
+			doc.AddBlock(block)
+		}
+		if err := checkNotInProfile(block); err != nil {
+			return nil, err
+		}
+		return []*dom.IPAliasesBlock{block}, nil
+	}
+
+	result := make([]*dom.IPAliasesBlock, 0, len(opt.blocks))
+	for _, idOrName := range opt.blocks {
+		block := doc.IPsBlockByIdOrName(idOrName)
+		if block == nil {
+			return nil, fmt.Errorf("blockId: %s; %w", idOrName, common.ErrBlockNotFound)
+		}
+		if err := checkNotInProfile(block); err != nil {
+			return nil, err
+		}
+		result = append(result, block)
+	}
+	return result, nil
+}
+
+func checkNotInProfile(block *dom.IPAliasesBlock) error {
+	if other, ok := block.Label(labelProfile); ok && other != "" {
+		return fmt.Errorf("block %s already belongs to profile %s; %w", common.BlockTitle(block), other, common.ErrWrongArgumentValue)
+	}
+	return nil
+}
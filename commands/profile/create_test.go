@@ -0,0 +1,114 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestProfileCreateCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "create - from blocks",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"dev", "-b", "1", "-b", "staging", "--group", "env"},
+				InputFile:  "testdata/blocks.txt",
+				OutputFile: "testdata/create/create__from_blocks__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "create - existing block named as profile",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"local"},
+				InputFile:  "testdata/blocks.txt",
+				OutputFile: "testdata/create/create__existing_block__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "create - new block",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"prod-readonly", "-g", "env", "-c", "Production, read only"},
+				InputFile:  "testdata/blocks.txt",
+				OutputFile: "testdata/create/create__new_block__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "create - locked block, forced",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"vpn", "-b", "corp-vpn", "--force"},
+				InputFile:  "testdata/blocks.txt",
+				OutputFile: "testdata/create/create_forced__locked_block__result.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "create error - profile exists",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"tools", "-b", "1"},
+				InputFile: "testdata/profiles.txt",
+				ErrorText: "profile tools; entry already exists",
+			},
+			Want: false,
+		},
+		{
+			Name: "create error - block belongs to other profile",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"dev", "-b", "local"},
+				InputFile: "testdata/profiles.txt",
+				ErrorText: "block [1] local already belongs to profile local",
+			},
+			Want: false,
+		},
+		{
+			Name: "create error - block named as profile belongs to other profile",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"staging"},
+				InputFile: "testdata/profiles.txt",
+				ErrorText: "block [2] staging already belongs to profile staging-tunnel",
+			},
+			Want: false,
+		},
+		{
+			Name: "create error - block not found",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"dev", "-b", "missing"},
+				InputFile: "testdata/blocks.txt",
+				ErrorText: "blockId: missing; block not found",
+			},
+			Want: false,
+		},
+		{
+			Name: "create error - locked block",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"vpn", "-b", "3"},
+				InputFile: "testdata/blocks.txt",
+				ErrorText: "block [3] corp-vpn is locked by ansible, use --force to change it",
+			},
+			Want: false,
+		},
+		{
+			Name: "create error - wrong name",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"my profile"},
+				InputFile: "testdata/blocks.txt",
+				ErrorText: `profile "my profile" must start with a letter or digit`,
+			},
+			Want: false,
+		},
+		{
+			Name: "create error - no name",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"-g", "env"},
+				InputFile: "testdata/blocks.txt",
+				ErrorText: "profile name expected",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestProfileCreateCommand", func() *cobra.Command { return NewCmdProfileCreate() })
+}
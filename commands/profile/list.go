@@ -0,0 +1,136 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/iotools"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+type outFormat int
+
+const (
+	fmtText outFormat = iota
+	fmtJson outFormat = iota
+	fmtYaml outFormat = iota
+)
+
+var (
+	formats = map[string]outFormat{
+		"":              fmtText,
+		common.TfmtText: fmtText,
+		common.TfmtJson: fmtJson,
+		common.TfmtYaml: fmtYaml,
+	}
+)
+
+type ProfileListOptions struct {
+	command      *cobra.Command
+	output       string
+	outputFormat outFormat
+	noHeaders    bool
+}
+
+func NewCmdProfileList() *cobra.Command {
+
+	opt := &ProfileListOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "list [(-o|--output)=name]",
+		Short:   fmt.Sprintf("Lists profiles defined in %s", hosts.EtcHosts.Path()),
+		Aliases: []string{"ls"},
+		Args:    cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.RunCliCommand(opt, cmd, args)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opt.noHeaders, "no-headers", opt.noHeaders, "Disable printing headers")
+	cmd.Flags().StringVarP(&opt.output, "output", "o", opt.output, fmt.Sprintf("Output format. One of %s", strings.Join(maps.Keys(formats), ",")))
+
+	return cmd
+}
+
+func (opt *ProfileListOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+
+	var ok bool
+	opt.outputFormat, ok = formats[opt.output]
+	if !ok {
+		return fmt.Errorf("value %v is not support; %w", opt.output, common.ErrNotSupportedOutputFormat)
+	}
+
+	return nil
+}
+
+func (opt *ProfileListOptions) Validate() error {
+	if len(opt.command.Flags().Args()) > 0 {
+		return common.ErrTooManyArguments
+	}
+	return nil
+}
+
+func (opt *ProfileListOptions) Execute() error {
+	src := common.HostsSource(opt.command.Context())
+	doc, err := src.Load()
+	cobra.CheckErr(err)
+
+	models := make([]*ProfileModel, 0)
+	for _, p := range findProfiles(doc) {
+		models = append(models, newProfileModel(p))
+	}
+
+	switch opt.outputFormat {
+	case fmtText:
+		err = writeDataAsText(opt, models)
+	case fmtJson:
+		err = writeDataAsJson(opt, models)
+	case fmtYaml:
+		err = writeDataAsYaml(opt, models)
+	default:
+		panic("unknown output format")
+	}
+	cobra.CheckErr(err)
+
+	return nil
+}
+
+func writeDataAsText(opt *ProfileListOptions, models []*ProfileModel) error {
+	err := iotools.PrintTabbed(opt.command.OutOrStdout(), nil, 2, func(w io.Writer) error {
+		if !opt.noHeaders {
+			fmt.Fprintln(w, "NAME\tGROUP\tALIASES\tBLOCKS")
+		}
+		for _, m := range models {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", m.Name, m.Group, strconv.Itoa(m.Aliases), strings.Join(m.Blocks, ", "))
+		}
+		return nil
+	})
+	return err
+}
+
+func writeDataAsJson(opt *ProfileListOptions, models []*ProfileModel) error {
+	buff, err := json.Marshal(models)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opt.command.OutOrStdout(), string(buff))
+	return nil
+}
+
+func writeDataAsYaml(opt *ProfileListOptions, models []*ProfileModel) error {
+	buff, err := yaml.Marshal(models)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opt.command.OutOrStdout(), string(buff))
+	return nil
+}
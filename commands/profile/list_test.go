@@ -0,0 +1,60 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestProfileListCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "list - profiles",
+			Args: cmdtest.ITArgs{
+				Args:       []string{},
+				InputFile:  "testdata/profiles.txt",
+				StdoutFile: "testdata/list/list__profiles__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "list json - profiles",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-o", "json"},
+				InputFile:  "testdata/profiles.txt",
+				StdoutFile: "testdata/list/list_json__profiles__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "list yaml - profiles",
+			Args: cmdtest.ITArgs{
+				Args:       []string{"-o", "yaml"},
+				InputFile:  "testdata/profiles.txt",
+				StdoutFile: "testdata/list/list_yaml__profiles__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "list - no profiles",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"--no-headers"},
+				InputFile: "testdata/blocks.txt",
+				Stdout:    "",
+			},
+			Want: true,
+		},
+		{
+			Name: "list error - output format",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"-o", "wide"},
+				InputFile: "testdata/profiles.txt",
+				ErrorText: "not supported output format",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestProfileListCommand", func() *cobra.Command { return NewCmdProfileList() })
+}
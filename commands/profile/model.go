@@ -0,0 +1,148 @@
+package profile
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/0xcfff/hostsctl/iptools"
+	"golang.org/x/exp/slices"
+)
+
+// Block labels defining profiles, e.g. "# @profile: staging-tunnel" and "# @profile-group: env"
+const (
+	labelProfile = "profile"
+	labelGroup   = "profile-group"
+)
+
+const (
+	statusActive   = "active"
+	statusInactive = "inactive"
+	statusPartial  = "partial"
+	statusEmpty    = "empty"
+)
+
+var (
+	rxProfileName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+)
+
+// Named set of blocks which entries are enabled or disabled together
+type profile struct {
+	name   string
+	group  string
+	blocks []*dom.IPAliasesBlock
+}
+
+type ProfileModel struct {
+	Name    string   `json:"name"            yaml:"name"`
+	Group   string   `json:"group,omitempty" yaml:"group,omitempty"`
+	Status  string   `json:"status"          yaml:"status"`
+	Blocks  []string `json:"blocks"          yaml:"blocks"`
+	Aliases int      `json:"count"           yaml:"count"`
+}
+
+// Returns profiles defined by the blocks labels in order of their first block
+func findProfiles(doc *dom.Document) []*profile {
+	result := make([]*profile, 0)
+	for _, block := range doc.IPBlocks() {
+		name, ok := block.Label(labelProfile)
+		if !ok || name == "" {
+			continue
+		}
+		idx := slices.IndexFunc(result, func(p *profile) bool { return p.name == name })
+		if idx < 0 {
+			result = append(result, &profile{name: name})
+			idx = len(result) - 1
+		}
+		p := result[idx]
+		p.blocks = append(p.blocks, block)
+		if group, ok := block.Label(labelGroup); ok && p.group == "" {
+			p.group = group
+		}
+	}
+	return result
+}
+
+func findProfile(profiles []*profile, name string) (*profile, error) {
+	idx := slices.IndexFunc(profiles, func(p *profile) bool { return p.name == name })
+	if idx < 0 {
+		return nil, fmt.Errorf("profile %s; %w", name, common.ErrProfileNotFound)
+	}
+	return profiles[idx], nil
+}
+
+// Returns error if the profile or group name can't be written as a block label value
+func validateName(kind string, name string) error {
+	if !rxProfileName.MatchString(name) {
+		return fmt.Errorf("%s %q must start with a letter or digit and contain only letters, digits and '_.-'; %w", kind, name, common.ErrWrongArgumentValue)
+	}
+	return nil
+}
+
+func (p *profile) entries() []*dom.IPAliasesEntry {
+	result := make([]*dom.IPAliasesEntry, 0)
+	for _, block := range p.blocks {
+		result = append(result, block.AliasEntries()...)
+	}
+	return result
+}
+
+// Returns active if all entries of the profile are enabled, inactive if all are disabled
+func (p *profile) status() string {
+	enabled := 0
+	entries := p.entries()
+	for _, ent := range entries {
+		if !ent.Disabled() {
+			enabled += 1
+		}
+	}
+	switch {
+	case len(entries) == 0:
+		return statusEmpty
+	case enabled == len(entries):
+		return statusActive
+	case enabled == 0:
+		return statusInactive
+	default:
+		return statusPartial
+	}
+}
+
+// Enables or disables all entries of the profile, system aliases are disabled only if forced
+func (p *profile) toggle(disable bool, system *iptools.SystemAliasCatalog, force bool) error {
+	changed := make([]*dom.IPAliasesEntry, 0)
+	for _, ent := range p.entries() {
+		if ent.Disabled() == disable {
+			continue
+		}
+		if disable && !force {
+			for _, alias := range ent.Aliases() {
+				if system.IsSystemAlias(ent.IP(), alias) {
+					return fmt.Errorf("profile %s contains system alias %s, use --force to disable it; %w", p.name, alias, common.ErrWrongArgumentValue)
+				}
+			}
+		}
+		changed = append(changed, ent)
+	}
+	for _, ent := range changed {
+		ent.SetDisabled(disable)
+	}
+	return nil
+}
+
+func newProfileModel(p *profile) *ProfileModel {
+	m := &ProfileModel{
+		Name:   p.name,
+		Group:  p.group,
+		Status: p.status(),
+		Blocks: make([]string, 0, len(p.blocks)),
+	}
+	for _, block := range p.blocks {
+		m.Blocks = append(m.Blocks, common.BlockTitle(block))
+	}
+	for _, ent := range p.entries() {
+		m.Aliases += len(ent.Aliases())
+	}
+	return m
+}
@@ -0,0 +1,25 @@
+package profile
+
+import "github.com/spf13/cobra"
+
+func NewCmdProfile() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile [command]",
+		Short: "Manage profiles, named sets of IP aliases blocks switched on and off together",
+		Long: `Manage profiles, named sets of IP aliases blocks switched on and off together.
+
+A block belongs to a profile if its header has "# @profile: <name>" label, profiles with
+the same "# @profile-group: <group>" label are mutually exclusive. Entries of inactive
+profiles are commented out.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(cmd.Help())
+		},
+	}
+	cmd.AddCommand(NewCmdProfileCreate())
+	cmd.AddCommand(NewCmdProfileList())
+	cmd.AddCommand(NewCmdProfileStatus())
+	cmd.AddCommand(NewCmdProfileActivate())
+	cmd.AddCommand(NewCmdProfileDeactivate())
+
+	return cmd
+}
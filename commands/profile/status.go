@@ -0,0 +1,81 @@
+package profile
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/0xcfff/hostsctl/commands/common"
+	"github.com/0xcfff/hostsctl/hosts"
+	"github.com/0xcfff/hostsctl/iotools"
+	"github.com/spf13/cobra"
+)
+
+type ProfileStatusOptions struct {
+	command   *cobra.Command
+	names     []string
+	noHeaders bool
+}
+
+func NewCmdProfileStatus() *cobra.Command {
+
+	opt := &ProfileStatusOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "status [name]...",
+		Short: fmt.Sprintf("Shows which profiles are active in %s", hosts.EtcHosts.Path()),
+		Long: fmt.Sprintf(`Shows which profiles are active in %s.
+
+A profile is active if all its IP aliases are enabled, inactive if all are commented out
+and partial otherwise.`, hosts.EtcHosts.Path()),
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.RunCliCommand(opt, cmd, args)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opt.noHeaders, "no-headers", opt.noHeaders, "Disable printing headers")
+
+	return cmd
+}
+
+func (opt *ProfileStatusOptions) Complete(cmd *cobra.Command, args []string) error {
+
+	opt.command = cmd
+	opt.names = cmd.Flags().Args()
+
+	return nil
+}
+
+func (opt *ProfileStatusOptions) Validate() error {
+	return nil
+}
+
+func (opt *ProfileStatusOptions) Execute() error {
+	src := common.HostsSource(opt.command.Context())
+	doc, err := src.Load()
+	cobra.CheckErr(err)
+
+	profiles := findProfiles(doc)
+	if len(opt.names) > 0 {
+		selected := make([]*profile, 0, len(opt.names))
+		for _, name := range opt.names {
+			p, err := findProfile(profiles, name)
+			cobra.CheckErr(err)
+			selected = append(selected, p)
+		}
+		profiles = selected
+	}
+
+	err = iotools.PrintTabbed(opt.command.OutOrStdout(), nil, 2, func(w io.Writer) error {
+		if !opt.noHeaders {
+			fmt.Fprintln(w, "NAME\tGROUP\tSTATUS")
+		}
+		for _, p := range profiles {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", p.name, p.group, p.status())
+		}
+		return nil
+	})
+	cobra.CheckErr(err)
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/0xcfff/hostsctl/commands/cmdtest"
+	"github.com/spf13/cobra"
+)
+
+func TestProfileStatusCommand(t *testing.T) {
+	tests := []cmdtest.ITTest{
+		{
+			Name: "status - all profiles",
+			Args: cmdtest.ITArgs{
+				Args:       []string{},
+				InputFile:  "testdata/profiles.txt",
+				StdoutFile: "testdata/status/status__all_profiles__output.txt",
+			},
+			Want: true,
+		},
+		{
+			Name: "status - selected profiles",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"--no-headers", "vpn", "local"},
+				InputFile: "testdata/profiles.txt",
+				Stdout:    "vpn    env  inactive\nlocal  env  active\n",
+			},
+			Want: true,
+		},
+		{
+			Name: "status error - profile not found",
+			Args: cmdtest.ITArgs{
+				Args:      []string{"local", "missing"},
+				InputFile: "testdata/profiles.txt",
+				ErrorText: "profile missing; profile not found",
+			},
+			Want: false,
+		},
+	}
+
+	cmdtest.RunIntergationTests(t, tests, "TestProfileStatusCommand", func() *cobra.Command { return NewCmdProfileStatus() })
+}
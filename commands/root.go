@@ -1,9 +1,19 @@
 package commands
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
 	"github.com/0xcfff/hostsctl/commands/alias"
+	"github.com/0xcfff/hostsctl/commands/apply"
 	"github.com/0xcfff/hostsctl/commands/block"
+	"github.com/0xcfff/hostsctl/commands/common"
 	"github.com/0xcfff/hostsctl/commands/database"
+	"github.com/0xcfff/hostsctl/commands/exec"
+	"github.com/0xcfff/hostsctl/commands/gc"
+	"github.com/0xcfff/hostsctl/commands/profile"
 	"github.com/0xcfff/hostsctl/commands/version"
 	"github.com/spf13/cobra"
 )
@@ -12,19 +22,85 @@ type RootParams struct {
 	Version string
 }
 
+type RootOptions struct {
+	hostsFile   string
+	backupDir   string
+	lockTimeout time.Duration
+}
+
 func NewCmdRoot(p RootParams) *cobra.Command {
+	opt := &RootOptions{
+		lockTimeout: common.DefaultLockTimeout,
+	}
+
 	cmd := &cobra.Command{
 		Short: "hostsctl manages ip to hostname mappings (usually stored in /etc/hosts)",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			ctx := opt.applyHostsFile(cmd)
+			ctx = opt.applyBackupDir(ctx)
+			ctx = applySystemAliases(ctx)
+			ctx = common.WithLockTimeout(ctx, opt.lockTimeout)
+			cmd.SetContext(ctx)
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			cobra.CheckErr(cmd.Help())
 		},
 	}
 
+	cmd.PersistentFlags().StringVar(&opt.hostsFile, "hosts-file", opt.hostsFile, "Path to hosts file to work with (overrides "+common.EnvHostsFile+" environment variable)")
+	cmd.PersistentFlags().StringVar(&opt.backupDir, "backup-dir", opt.backupDir, "Directory to store hosts file backups in (overrides "+common.EnvBackupDir+" environment variable)")
+	cmd.PersistentFlags().DurationVar(&opt.lockTimeout, "lock-timeout", opt.lockTimeout, "Time to wait for hosts file lock held by another hostsctl process")
+
 	cmd.AddCommand(version.NewCmdVersion(version.VersionParams{
 		Version: p.Version,
 	}))
 	cmd.AddCommand(block.NewCmdBlock())
 	cmd.AddCommand(alias.NewCmdAlias())
 	cmd.AddCommand(database.NewCmdDatabase())
+	cmd.AddCommand(apply.NewCmdApply())
+	cmd.AddCommand(gc.NewCmdGc())
+	cmd.AddCommand(exec.NewCmdExec())
+	cmd.AddCommand(profile.NewCmdProfile())
 	return cmd
 }
+
+// Returns command context with hosts file override taken from
+// the --hosts-file flag or the environment variable if any
+func (opt *RootOptions) applyHostsFile(cmd *cobra.Command) context.Context {
+	ctx := cmd.Context()
+	hostsFile := opt.hostsFile
+	if hostsFile == "" {
+		hostsFile = os.Getenv(common.EnvHostsFile)
+	}
+	if hostsFile != "" {
+		ctx = common.WithCustomHostsFile(ctx, hostsFile)
+	}
+	return ctx
+}
+
+// Returns context with backups directory override taken from
+// the --backup-dir flag or the environment variable if any
+func (opt *RootOptions) applyBackupDir(ctx context.Context) context.Context {
+	backupDir := opt.backupDir
+	if backupDir == "" {
+		backupDir = os.Getenv(common.EnvBackupDir)
+	}
+	if backupDir != "" {
+		ctx = common.WithCustomBackupDir(ctx, backupDir)
+	}
+	return ctx
+}
+
+// Returns context with system aliases catalog extended with
+// the aliases from the environment variable if any
+func applySystemAliases(ctx context.Context) context.Context {
+	value := os.Getenv(common.EnvSystemAliases)
+	if value == "" {
+		return ctx
+	}
+	catalog := common.SystemAliases(ctx).Clone()
+	if err := common.AddSystemAliases(catalog, value); err != nil {
+		cobra.CheckErr(fmt.Errorf("%s, %w", common.EnvSystemAliases, err))
+	}
+	return common.WithSystemAliases(ctx, catalog)
+}
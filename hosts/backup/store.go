@@ -0,0 +1,240 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	idLayout      = "20060102T150405.000000Z"
+	dataExt       = ".hosts"
+	metaExt       = ".json"
+	defaultDirExt = ".backups"
+)
+
+var (
+	ErrSnapshotNotFound = errors.New("backup not found")
+	ErrNoSnapshots      = errors.New("no backups found")
+	ErrChecksumMismatch = errors.New("backup checksum mismatch")
+)
+
+// Hosts file snapshot stored in the backup directory
+type Snapshot struct {
+	Id          string    `json:"id"                    yaml:"id"`
+	Created     time.Time `json:"created"               yaml:"created"`
+	Description string    `json:"description,omitempty" yaml:"description,omitempty"`
+	Source      string    `json:"source,omitempty"      yaml:"source,omitempty"`
+	Size        int64     `json:"size"                  yaml:"size"`
+	Checksum    string    `json:"checksum"              yaml:"checksum"`
+}
+
+// Defines which snapshots are kept when the store is pruned.
+// Zero values mean no limit
+type RetentionPolicy struct {
+	KeepLast int           // max number of the most recent snapshots to keep
+	KeepFor  time.Duration // max age of snapshots to keep
+}
+
+// Directory of timestamped hosts file snapshots
+type Store struct {
+	dir string
+	fs  afero.Fs
+	now func() time.Time
+}
+
+// Returns default backup directory for the hosts file, it is located next to the file
+func DefaultDir(hostsPath string) string {
+	return hostsPath + defaultDirExt
+}
+
+func NewStore(dir string, fs afero.Fs) *Store {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+	return &Store{
+		dir: dir,
+		fs:  fs,
+		now: time.Now,
+	}
+}
+
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Stores data as a new snapshot
+func (s *Store) Create(data []byte, source string, description string) (*Snapshot, error) {
+	err := s.fs.MkdirAll(s.dir, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("can't create backup directory %s, %w", s.dir, err)
+	}
+
+	created := s.now().UTC()
+	id := created.Format(idLayout)
+	for s.exists(id) {
+		created = created.Add(time.Microsecond)
+		id = created.Format(idLayout)
+	}
+
+	snap := &Snapshot{
+		Id:          id,
+		Created:     created,
+		Description: description,
+		Source:      source,
+		Size:        int64(len(data)),
+		Checksum:    checksum(data),
+	}
+
+	err = afero.WriteFile(s.fs, s.dataPath(id), data, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("can't write backup %s, %w", id, err)
+	}
+
+	meta, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	err = afero.WriteFile(s.fs, s.metaPath(id), meta, 0o600)
+	if err != nil {
+		s.fs.Remove(s.dataPath(id))
+		return nil, fmt.Errorf("can't write backup %s, %w", id, err)
+	}
+
+	return snap, nil
+}
+
+// Returns all snapshots ordered from the oldest to the newest
+func (s *Store) List() ([]*Snapshot, error) {
+	result := make([]*Snapshot, 0)
+
+	infos, err := afero.ReadDir(s.fs, s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return result, nil
+		}
+		return nil, fmt.Errorf("can't read backup directory %s, %w", s.dir, err)
+	}
+
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasSuffix(info.Name(), metaExt) {
+			continue
+		}
+		data, err := afero.ReadFile(s.fs, filepath.Join(s.dir, info.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("can't read backup %s, %w", info.Name(), err)
+		}
+		snap := &Snapshot{}
+		err = json.Unmarshal(data, snap)
+		if err != nil || snap.Id == "" {
+			// not a backup description, skip it
+			continue
+		}
+		result = append(result, snap)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Id < result[j].Id })
+	return result, nil
+}
+
+// Returns snapshot with the specified id
+func (s *Store) Get(id string) (*Snapshot, error) {
+	snaps, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, snap := range snaps {
+		if snap.Id == id {
+			return snap, nil
+		}
+	}
+	return nil, fmt.Errorf("id: %s; %w", id, ErrSnapshotNotFound)
+}
+
+// Returns the most recent snapshot
+func (s *Store) Latest() (*Snapshot, error) {
+	snaps, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	if len(snaps) == 0 {
+		return nil, fmt.Errorf("%s; %w", s.dir, ErrNoSnapshots)
+	}
+	return snaps[len(snaps)-1], nil
+}
+
+// Reads snapshot content and verifies its checksum
+func (s *Store) Read(snap *Snapshot) ([]byte, error) {
+	data, err := afero.ReadFile(s.fs, s.dataPath(snap.Id))
+	if err != nil {
+		return nil, fmt.Errorf("can't read backup %s, %w", snap.Id, err)
+	}
+	if checksum(data) != snap.Checksum {
+		return nil, fmt.Errorf("id: %s; %w", snap.Id, ErrChecksumMismatch)
+	}
+	return data, nil
+}
+
+// Deletes snapshots not satisfying the retention policy, returns deleted snapshots
+func (s *Store) Prune(policy RetentionPolicy) ([]*Snapshot, error) {
+	snaps, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	deleted := make([]*Snapshot, 0)
+	for i, snap := range snaps {
+		tooMany := policy.KeepLast > 0 && len(snaps)-i > policy.KeepLast
+		tooOld := policy.KeepFor > 0 && now.Sub(snap.Created) > policy.KeepFor
+		if !tooMany && !tooOld {
+			continue
+		}
+		err = s.Delete(snap)
+		if err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, snap)
+	}
+	return deleted, nil
+}
+
+// Deletes the snapshot from the store
+func (s *Store) Delete(snap *Snapshot) error {
+	err := s.fs.Remove(s.metaPath(snap.Id))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("can't delete backup %s, %w", snap.Id, err)
+	}
+	err = s.fs.Remove(s.dataPath(snap.Id))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("can't delete backup %s, %w", snap.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) exists(id string) bool {
+	_, err := s.fs.Stat(s.metaPath(id))
+	return err == nil
+}
+
+func (s *Store) dataPath(id string) string {
+	return filepath.Join(s.dir, id+dataExt)
+}
+
+func (s *Store) metaPath(id string) string {
+	return filepath.Join(s.dir, id+metaExt)
+}
+
+func checksum(data []byte) string {
+	h := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(h[:])
+}
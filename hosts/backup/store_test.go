@@ -0,0 +1,112 @@
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStore(fs afero.Fs, start time.Time) (*Store, *time.Time) {
+	now := start
+	store := NewStore("/etc/hosts.backups", fs)
+	store.now = func() time.Time { return now }
+	return store, &now
+}
+
+func TestStore_CreateAndRead(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	store, _ := newTestStore(fs, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	snap, err := store.Create([]byte("127.0.0.1 localhost\n"), "/etc/hosts", "initial")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "20260102T030405.000000Z", snap.Id)
+	assert.Equal(t, "initial", snap.Description)
+	assert.Equal(t, int64(20), snap.Size)
+	assert.Equal(t, "sha256:", snap.Checksum[:7])
+
+	found, err := store.Get(snap.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, snap.Checksum, found.Checksum)
+
+	data, err := store.Read(found)
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1 localhost\n", string(data))
+}
+
+func TestStore_CreateSameTime(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	store, _ := newTestStore(fs, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	s1, _ := store.Create([]byte("a"), "", "")
+	s2, _ := store.Create([]byte("b"), "", "")
+
+	assert.NotEqual(t, s1.Id, s2.Id)
+	latest, err := store.Latest()
+	assert.NoError(t, err)
+	assert.Equal(t, s2.Id, latest.Id)
+}
+
+func TestStore_ReadCorrupted(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	store, _ := newTestStore(fs, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	snap, _ := store.Create([]byte("127.0.0.1 localhost\n"), "", "")
+	afero.WriteFile(fs, store.dataPath(snap.Id), []byte("127.0.0.1 evil\n"), 0o600)
+
+	_, err := store.Read(snap)
+
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+}
+
+func TestStore_Empty(t *testing.T) {
+	store, _ := newTestStore(afero.NewMemMapFs(), time.Now())
+
+	snaps, err := store.List()
+	assert.NoError(t, err)
+	assert.Empty(t, snaps)
+
+	_, err = store.Latest()
+	assert.ErrorIs(t, err, ErrNoSnapshots)
+
+	_, err = store.Get("20260102T030405.000000Z")
+	assert.ErrorIs(t, err, ErrSnapshotNotFound)
+}
+
+func TestStore_Prune(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetentionPolicy
+		want   []string
+	}{
+		{"no limits", RetentionPolicy{}, []string{"d1", "d2", "d3", "d4"}},
+		{"keep last", RetentionPolicy{KeepLast: 2}, []string{"d3", "d4"}},
+		{"keep for", RetentionPolicy{KeepFor: 36 * time.Hour}, []string{"d3", "d4"}},
+		{"keep last and for", RetentionPolicy{KeepLast: 3, KeepFor: 60 * time.Hour}, []string{"d2", "d3", "d4"}},
+		{"keep last stricter", RetentionPolicy{KeepLast: 1, KeepFor: 60 * time.Hour}, []string{"d4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			store, now := newTestStore(afero.NewMemMapFs(), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+			for i := 1; i <= 4; i++ {
+				store.Create([]byte{byte(i)}, "", "d"+string(rune('0'+i)))
+				*now = now.Add(24 * time.Hour)
+			}
+			*now = now.Add(-24 * time.Hour)
+
+			// act
+			_, err := store.Prune(tt.policy)
+
+			// assert
+			assert.NoError(t, err)
+			snaps, _ := store.List()
+			descriptions := make([]string, 0)
+			for _, s := range snaps {
+				descriptions = append(descriptions, s.Description)
+			}
+			assert.Equal(t, tt.want, descriptions)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package dom
+
+// Kind of difference between two documents
+type ChangeKind string
+
+const (
+	BlockAdded    ChangeKind = "block-added"
+	BlockRemoved  ChangeKind = "block-removed"
+	BlockRenamed  ChangeKind = "block-renamed"
+	EntryAdded    ChangeKind = "entry-added"
+	EntryRemoved  ChangeKind = "entry-removed"
+	EntryEnabled  ChangeKind = "entry-enabled"
+	EntryDisabled ChangeKind = "entry-disabled"
+	AliasMoved    ChangeKind = "alias-moved"
+)
+
+// Identifies IP aliases block a change belongs to
+type BlockRef struct {
+	Id   int
+	Name string
+}
+
+// Single semantic difference between two documents
+type Change struct {
+	Kind     ChangeKind
+	Block    BlockRef  // block of the new document, or of the old one if the block is removed
+	OldBlock *BlockRef // previous block identifiers, set for renamed blocks only
+	IP       string
+	OldIP    string // previous IP, set for moved aliases only
+	Alias    string
+}
+
+type aliasKey struct {
+	ip    string
+	alias string
+}
+
+// Compares IP aliases blocks of the documents and returns the differences.
+// Blocks are matched by id and name, aliases are matched by IP and name,
+// comments and formatting are not compared
+func Diff(from *Document, to *Document) []*Change {
+	oldBlocks := from.IPBlocks()
+	newBlocks := to.IPBlocks()
+	pairs := matchBlocks(oldBlocks, newBlocks)
+
+	changes := make([]*Change, 0)
+	for _, nb := range newBlocks {
+		ob := pairs[nb]
+		ref := blockRef(nb)
+		if ob == nil {
+			changes = append(changes, &Change{Kind: BlockAdded, Block: ref})
+		} else if ob.Id() != nb.Id() || ob.Name() != nb.Name() {
+			oldRef := blockRef(ob)
+			changes = append(changes, &Change{Kind: BlockRenamed, Block: ref, OldBlock: &oldRef})
+		}
+		changes = append(changes, diffBlockEntries(ob, nb, ref)...)
+	}
+
+	matched := make(map[*IPAliasesBlock]bool)
+	for _, ob := range pairs {
+		matched[ob] = true
+	}
+	for _, ob := range oldBlocks {
+		if matched[ob] {
+			continue
+		}
+		ref := blockRef(ob)
+		changes = append(changes, &Change{Kind: BlockRemoved, Block: ref})
+		changes = append(changes, diffBlockEntries(ob, nil, ref)...)
+	}
+
+	return changes
+}
+
+// Matches new blocks to the old ones, first by id and name, then by name only,
+// then by common aliases and finally by id only
+func matchBlocks(oldBlocks []*IPAliasesBlock, newBlocks []*IPAliasesBlock) map[*IPAliasesBlock]*IPAliasesBlock {
+	pairs := make(map[*IPAliasesBlock]*IPAliasesBlock)
+	used := make(map[*IPAliasesBlock]bool)
+
+	matchers := []func(ob, nb *IPAliasesBlock) bool{
+		func(ob, nb *IPAliasesBlock) bool { return ob.Id() == nb.Id() && ob.Name() == nb.Name() },
+		func(ob, nb *IPAliasesBlock) bool { return ob.Name() != "" && ob.Name() == nb.Name() },
+		haveCommonAliases,
+		func(ob, nb *IPAliasesBlock) bool { return ob.Id() == nb.Id() },
+	}
+	for _, match := range matchers {
+		for _, nb := range newBlocks {
+			if pairs[nb] != nil {
+				continue
+			}
+			for _, ob := range oldBlocks {
+				if !used[ob] && match(ob, nb) {
+					pairs[nb] = ob
+					used[ob] = true
+					break
+				}
+			}
+		}
+	}
+	return pairs
+}
+
+func diffBlockEntries(ob *IPAliasesBlock, nb *IPAliasesBlock, ref BlockRef) []*Change {
+	oldKeys, oldDisabled := blockAliases(ob)
+	newKeys, newDisabled := blockAliases(nb)
+
+	removed := make([]aliasKey, 0)
+	for _, k := range oldKeys {
+		if _, ok := newDisabled[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	added := make([]aliasKey, 0)
+	for _, k := range newKeys {
+		if _, ok := oldDisabled[k]; !ok {
+			added = append(added, k)
+		}
+	}
+
+	// an alias removed from one IP and added to another one is moved
+	moved := make(map[aliasKey]aliasKey)
+	if ob != nil && nb != nil {
+		removedByAlias := groupByAlias(removed)
+		addedByAlias := groupByAlias(added)
+		for alias, rk := range removedByAlias {
+			ak := addedByAlias[alias]
+			if len(rk) == 1 && len(ak) == 1 {
+				moved[ak[0]] = rk[0]
+			}
+		}
+	}
+
+	changes := make([]*Change, 0)
+	for _, k := range removed {
+		if isMovedFrom(moved, k) {
+			continue
+		}
+		changes = append(changes, &Change{Kind: EntryRemoved, Block: ref, IP: k.ip, Alias: k.alias})
+	}
+	for _, k := range newKeys {
+		if from, ok := moved[k]; ok {
+			changes = append(changes, &Change{Kind: AliasMoved, Block: ref, IP: k.ip, OldIP: from.ip, Alias: k.alias})
+			continue
+		}
+		wasDisabled, existed := oldDisabled[k]
+		if !existed {
+			changes = append(changes, &Change{Kind: EntryAdded, Block: ref, IP: k.ip, Alias: k.alias})
+		} else if wasDisabled && !newDisabled[k] {
+			changes = append(changes, &Change{Kind: EntryEnabled, Block: ref, IP: k.ip, Alias: k.alias})
+		} else if !wasDisabled && newDisabled[k] {
+			changes = append(changes, &Change{Kind: EntryDisabled, Block: ref, IP: k.ip, Alias: k.alias})
+		}
+	}
+	return changes
+}
+
+// Returns aliases of the block in the order they are defined and their disabled state
+func blockAliases(block *IPAliasesBlock) ([]aliasKey, map[aliasKey]bool) {
+	keys := make([]aliasKey, 0)
+	disabled := make(map[aliasKey]bool)
+	if block == nil {
+		return keys, disabled
+	}
+	for _, ent := range block.AliasEntries() {
+		for _, alias := range ent.Aliases() {
+			k := aliasKey{ent.IP(), alias}
+			if _, ok := disabled[k]; !ok {
+				keys = append(keys, k)
+				disabled[k] = ent.Disabled()
+			} else if !ent.Disabled() {
+				disabled[k] = false
+			}
+		}
+	}
+	return keys, disabled
+}
+
+func haveCommonAliases(ob *IPAliasesBlock, nb *IPAliasesBlock) bool {
+	_, oldAliases := blockAliases(ob)
+	newKeys, _ := blockAliases(nb)
+	for _, k := range newKeys {
+		if _, ok := oldAliases[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func groupByAlias(keys []aliasKey) map[string][]aliasKey {
+	result := make(map[string][]aliasKey)
+	for _, k := range keys {
+		result[k.alias] = append(result[k.alias], k)
+	}
+	return result
+}
+
+func isMovedFrom(moved map[aliasKey]aliasKey, key aliasKey) bool {
+	for _, from := range moved {
+		if from == key {
+			return true
+		}
+	}
+	return false
+}
+
+func blockRef(block *IPAliasesBlock) BlockRef {
+	return BlockRef{Id: block.Id(), Name: block.Name()}
+}
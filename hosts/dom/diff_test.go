@@ -0,0 +1,64 @@
+package dom
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	const base = "127.0.0.1 localhost\n\n# [2] pet-prj1 - My project\n10.0.0.1 cats.example.org\n10.0.0.2 dogs.example.org\n"
+
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want []string
+	}{
+		{"same", base, base, []string{}},
+		{"formatting only", base, strings.ReplaceAll(base, " ", "    "), []string{}},
+		{"entry added", base, base + "10.0.0.3 birds.example.org\n", []string{"entry-added [2]pet-prj1 10.0.0.3 birds.example.org"}},
+		{"entry removed", base, strings.ReplaceAll(base, "10.0.0.2 dogs.example.org\n", ""), []string{"entry-removed [2]pet-prj1 10.0.0.2 dogs.example.org"}},
+		{"entry disabled", base, strings.ReplaceAll(base, "10.0.0.2 dogs", "# 10.0.0.2 dogs"), []string{"entry-disabled [2]pet-prj1 10.0.0.2 dogs.example.org"}},
+		{"entry enabled", strings.ReplaceAll(base, "10.0.0.2 dogs", "# 10.0.0.2 dogs"), base, []string{"entry-enabled [2]pet-prj1 10.0.0.2 dogs.example.org"}},
+		{"alias moved", base, strings.ReplaceAll(base, "10.0.0.2 dogs", "10.0.0.9 dogs"), []string{"alias-moved [2]pet-prj1 10.0.0.2->10.0.0.9 dogs.example.org"}},
+		{"block renamed", base, strings.ReplaceAll(base, "pet-prj1", "pet-prj2"), []string{"block-renamed [2]pet-prj1->[2]pet-prj2"}},
+		{"block id changed", base, strings.ReplaceAll(base, "[2]", "[7]"), []string{"block-renamed [2]pet-prj1->[7]pet-prj1"}},
+		{"block renamed and id changed", base, strings.ReplaceAll(base, "[2] pet-prj1", "[5] pet-prj5"), []string{"block-renamed [2]pet-prj1->[5]pet-prj5"}},
+		{"block added", base, base + "\n# [3] k8s\n10.1.0.1 node1\n", []string{"block-added [3]k8s", "entry-added [3]k8s 10.1.0.1 node1"}},
+		{"block removed", base + "\n# [3] k8s\n10.1.0.1 node1\n", base, []string{"block-removed [3]k8s", "entry-removed [3]k8s 10.1.0.1 node1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			from, _ := Read(strings.NewReader(tt.from))
+			to, _ := Read(strings.NewReader(tt.to))
+
+			// act
+			changes := Diff(from, to)
+
+			// assert
+			got := make([]string, 0)
+			for _, c := range changes {
+				got = append(got, formatChange(c))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func formatChange(c *Change) string {
+	block := fmt.Sprintf("[%d]%s", c.Block.Id, c.Block.Name)
+	switch c.Kind {
+	case BlockRenamed:
+		return fmt.Sprintf("%s [%d]%s->%s", c.Kind, c.OldBlock.Id, c.OldBlock.Name, block)
+	case BlockAdded, BlockRemoved:
+		return fmt.Sprintf("%s %s", c.Kind, block)
+	case AliasMoved:
+		return fmt.Sprintf("%s %s %s->%s %s", c.Kind, block, c.OldIP, c.IP, c.Alias)
+	default:
+		return fmt.Sprintf("%s %s %s %s", c.Kind, block, c.IP, c.Alias)
+	}
+}
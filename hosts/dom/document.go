@@ -56,7 +56,7 @@ func (doc *Document) IPBlocksByIdentifiers(id int, name string) []*IPAliasesBloc
 	allBlocks := doc.IPBlocks()
 	for _, b := range allBlocks {
 		added := false
-		if id != idNotSet && b.IdSet() && b.Id() == id {
+		if id != idNotSet && b.Id() == id {
 			selectedBlocks = append(selectedBlocks, b)
 			added = true
 		}
@@ -79,6 +79,17 @@ func (doc *Document) IPBlocks() []*IPAliasesBlock {
 	return blocks
 }
 
+// Returns IP blocks marked as locked
+func (doc *Document) LockedBlocks() []*IPAliasesBlock {
+	blocks := make([]*IPAliasesBlock, 0)
+	for _, blk := range doc.IPBlocks() {
+		if blk.Locked() {
+			blocks = append(blocks, blk)
+		}
+	}
+	return blocks
+}
+
 func (doc *Document) AddBlock(block Block) {
 	doc.blocks = append(doc.blocks, block)
 }
@@ -90,6 +101,102 @@ func (doc *Document) DeleteBlock(block Block) {
 	}
 }
 
+// Puts the new block in place of the old one, returns false if the old block is not found
+func (doc *Document) ReplaceBlock(old Block, new Block) bool {
+	idx := slices.Index(doc.blocks, old)
+	if idx == -1 {
+		return false
+	}
+	doc.blocks[idx] = new
+	return true
+}
+
+// Moves the block right before the target block, blank lines separating the block are moved with it
+func (doc *Document) MoveBlockBefore(block Block, target Block) bool {
+	if block == target || !slices.Contains(doc.blocks, target) {
+		return false
+	}
+	blanks, ok := doc.detachBlock(block)
+	if !ok {
+		return false
+	}
+	doc.insertBlock(slices.Index(doc.blocks, target), block, blanks)
+	return true
+}
+
+// Moves the block right after the target block and blank lines following it
+func (doc *Document) MoveBlockAfter(block Block, target Block) bool {
+	if block == target || !slices.Contains(doc.blocks, target) {
+		return false
+	}
+	blanks, ok := doc.detachBlock(block)
+	if !ok {
+		return false
+	}
+	idx := slices.Index(doc.blocks, target) + 1
+	if idx < len(doc.blocks) && doc.blocks[idx].Type() == Blanks {
+		idx++
+	}
+	doc.insertBlock(idx, block, blanks)
+	return true
+}
+
+// Moves the block to the beginning of the document
+func (doc *Document) MoveBlockToTop(block Block) bool {
+	blanks, ok := doc.detachBlock(block)
+	if !ok {
+		return false
+	}
+	doc.insertBlock(0, block, blanks)
+	return true
+}
+
+// Moves the block to the end of the document
+func (doc *Document) MoveBlockToBottom(block Block) bool {
+	blanks, ok := doc.detachBlock(block)
+	if !ok {
+		return false
+	}
+	doc.insertBlock(len(doc.blocks), block, blanks)
+	return true
+}
+
+// Removes the block together with blank lines following it,
+// the last block takes blank lines preceding it instead
+func (doc *Document) detachBlock(block Block) (Block, bool) {
+	idx := slices.Index(doc.blocks, block)
+	if idx == -1 {
+		return nil, false
+	}
+
+	var blanks Block
+	if idx+1 < len(doc.blocks) && doc.blocks[idx+1].Type() == Blanks {
+		blanks = doc.blocks[idx+1]
+		doc.blocks = slices.Delete(doc.blocks, idx, idx+2)
+	} else if idx == len(doc.blocks)-1 && idx > 0 && doc.blocks[idx-1].Type() == Blanks {
+		blanks = doc.blocks[idx-1]
+		doc.blocks = slices.Delete(doc.blocks, idx-1, idx+1)
+	} else {
+		doc.blocks = slices.Delete(doc.blocks, idx, idx+1)
+	}
+	return blanks, true
+}
+
+// Inserts the block and its blank lines at the index, blank lines follow the block
+// unless the block becomes the last one
+func (doc *Document) insertBlock(idx int, block Block, blanks Block) {
+	switch {
+	case blanks == nil:
+		doc.blocks = slices.Insert(doc.blocks, idx, block)
+	case idx < len(doc.blocks):
+		doc.blocks = slices.Insert(doc.blocks, idx, block, blanks)
+	case idx > 0 && doc.blocks[idx-1].Type() == Blanks:
+		doc.blocks = append(doc.blocks, block, blanks)
+	default:
+		doc.blocks = append(doc.blocks, blanks, block)
+	}
+}
+
 func (doc *Document) Normalize() bool {
 	normalized := false
 	for _, blk := range doc.blocks {
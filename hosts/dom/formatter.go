@@ -10,14 +10,14 @@ import (
 	"github.com/0xcfff/hostsctl/iotools"
 )
 
-func constructSyntax(doc *Document) *syntax.Document {
+func constructSyntax(doc *Document, fm FmtMode) *syntax.Document {
 	elements := make([]syntax.Element, 0)
 
 	for _, block := range doc.blocks {
 		switch block.Type() {
 		case IPList:
 			b := block.(*IPAliasesBlock)
-			bels := constructAliases(b)
+			bels := constructAliases(b, fm)
 			if len(elements) > 0 && elements[len(elements)-1].Type() != syntax.Empty {
 				elements = append(elements, syntax.NewEmptyLine())
 			}
@@ -40,13 +40,13 @@ func constructSyntax(doc *Document) *syntax.Document {
 	return syntax.NewDocument(elements)
 }
 
-func constructAliases(block *IPAliasesBlock) []syntax.Element {
+func constructAliases(block *IPAliasesBlock, fm FmtMode) []syntax.Element {
 	elements := make([]syntax.Element, 0)
 	if block.origHeader != nil {
 		for _, el := range block.origHeader {
 			elements = append(elements, el)
 		}
-	} else if block.id != idNotSet || block.name != "" || block.note != "" {
+	} else if block.id != idNotSet || block.name != "" || block.note != "" || block.locked || len(block.labels) > 0 {
 		sb := strings.Builder{}
 
 		// format block id and name prefix
@@ -59,6 +59,13 @@ func constructAliases(block *IPAliasesBlock) []syntax.Element {
 			sb.WriteRune(' ')
 			sb.WriteString(block.name)
 		}
+		if block.locked {
+			sb.WriteString(" - locked")
+			if block.lockedBy != "" {
+				sb.WriteString(" by ")
+				sb.WriteString(block.lockedBy)
+			}
+		}
 
 		// format notes
 		firstLine := true
@@ -86,6 +93,12 @@ func constructAliases(block *IPAliasesBlock) []syntax.Element {
 			el := syntax.NewCommentsLine(sb.String())
 			elements = append(elements, el)
 		}
+
+		// format metadata
+		for _, k := range block.labelKeys {
+			el := syntax.NewCommentsLine(fmt.Sprintf("@%s: %s", k, block.labels[k]))
+			elements = append(elements, el)
+		}
 	}
 	for _, el := range block.entries {
 		switch el.Type() {
@@ -93,8 +106,12 @@ func constructAliases(block *IPAliasesBlock) []syntax.Element {
 			ael := el.(*IPAliasesEntry)
 			ipAlias := ael.origElement
 			if ipAlias == nil {
-				ipAlias = syntax.NewIPMappingLine(ael.ip, ael.aliases, ael.note)
+				ipAlias = newIPMappingLine(ael)
 				ael.origElement = ipAlias
+			} else if fm == FmtReFormat && ael.disabled {
+				// commented out mappings are parsed as comments,
+				// convert them back to mappings to align them with the rest of the block
+				ipAlias = newIPMappingLine(ael)
 			}
 			elements = append(elements, ipAlias)
 		case Placeholder:
@@ -110,6 +127,13 @@ func constructAliases(block *IPAliasesBlock) []syntax.Element {
 	return elements
 }
 
+func newIPMappingLine(entry *IPAliasesEntry) *syntax.IPMappingLine {
+	if entry.disabled {
+		return syntax.NewDisabledIPMappingLine(entry.ip, entry.aliases, entry.note)
+	}
+	return syntax.NewIPMappingLine(entry.ip, entry.aliases, entry.note)
+}
+
 func constructComments(block *CommentsBlock) []syntax.Element {
 	elements := make([]syntax.Element, 0)
 	if block.origComments != nil {
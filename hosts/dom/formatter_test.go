@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/0xcfff/hostsctl/hosts/syntax"
 	"github.com/stretchr/testify/assert"
@@ -34,7 +35,7 @@ func Test_format_sameAsOriginal(t *testing.T) {
 			w := &strings.Builder{}
 
 			// act
-			fsdoc := constructSyntax(doc)
+			fsdoc := constructSyntax(doc, FmtDefault)
 			syntax.Write(w, fsdoc, syntax.FmtDefault)
 
 			// assert
@@ -43,3 +44,153 @@ func Test_format_sameAsOriginal(t *testing.T) {
 		})
 	}
 }
+
+func Test_format_disabledEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		fm      FmtMode
+		want    string
+	}{
+		{"keep - disabled parsed entry", "# [1] sys\n127.0.0.1 localhost\n# 10.0.0.1 foo", FmtKeep, "# [1] sys\n127.0.0.1 localhost\n# 10.0.0.1 foo"},
+		{"keep - entry disabled", "# [1] sys\n127.0.0.1 localhost\n10.0.0.1 foo # my foo", FmtKeep, "# [1] sys\n127.0.0.1 localhost\n# 10.0.0.1 foo     # my foo"},
+		{"keep - first entry disabled", "# [1] sys\n10.0.0.1 foo\n127.0.0.1 localhost", FmtKeep, "# [1] sys\n# 10.0.0.1 foo\n127.0.0.1 localhost"},
+		{"keep - placeholder of disabled entries removed", "# [1] sys\n# <<placeholder>>\n10.0.0.1 foo\n127.0.0.1 localhost", FmtKeep, "# [1] sys\n# 10.0.0.1 foo\n127.0.0.1 localhost"},
+		{"reformat - entry disabled", "# [1] sys\n127.0.0.1 localhost\n10.0.0.1 foo", FmtReFormat, "# [1] sys\n127.0.0.1   localhost\n# 10.0.0.1  foo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			doc, _ := Read(strings.NewReader(tt.content))
+			entries := doc.IPBlocks()[0].AliasEntriesByAlias("foo")
+			for _, e := range entries {
+				e.SetDisabled(true)
+			}
+			doc.Normalize()
+			w := &strings.Builder{}
+
+			// act
+			err := Write(w, doc, tt.fm)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, w.String())
+
+			// disabled entry is recognized after reading it back
+			rdoc, _ := Read(strings.NewReader(w.String()))
+			assert.Equal(t, 1, len(rdoc.IPBlocks()))
+			rentries := rdoc.IPBlocks()[0].AliasEntriesByAlias("foo")
+			assert.Equal(t, 1, len(rentries))
+			assert.True(t, rentries[0].Disabled())
+		})
+	}
+}
+
+func Test_format_lockedBlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		locked   bool
+		lockedBy string
+		want     string
+	}{
+		{"lock named block", "# [1] sys - System IPs\n127.0.0.1 localhost", true, "root", "# [1] sys - locked by root - System IPs\n127.0.0.1 localhost"},
+		{"lock block without note", "# [1] sys\n127.0.0.1 localhost", true, "", "# [1] sys - locked\n127.0.0.1 localhost"},
+		{"lock block without header", "127.0.0.1 localhost", true, "root", "# [*] - locked by root\n127.0.0.1 localhost"},
+		{"unlock block", "# [1] sys - locked by root - System IPs\n127.0.0.1 localhost", false, "", "# [1] sys - System IPs\n127.0.0.1 localhost"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			doc, _ := Read(strings.NewReader(tt.content))
+			doc.IPBlocks()[0].SetLocked(tt.locked, tt.lockedBy)
+			w := &strings.Builder{}
+
+			// act
+			err := Write(w, doc, FmtKeep)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, w.String())
+
+			// lock marker is recognized after reading it back
+			rdoc, _ := Read(strings.NewReader(w.String()))
+			rblock := rdoc.IPBlocks()[0]
+			assert.Equal(t, tt.locked, rblock.Locked())
+			assert.Equal(t, tt.lockedBy, rblock.LockedBy())
+			assert.Equal(t, doc.IPBlocks()[0].Note(), rblock.Note())
+		})
+	}
+}
+
+func Test_format_labels(t *testing.T) {
+	content := "# [4] k8s-local - Local cluster\n# @owner:  platform-team\n#   @source: kubectl\n10.0.0.1 node1"
+	tests := []struct {
+		name   string
+		modify func(blk *IPAliasesBlock)
+		want   string
+	}{
+		{"keep - unchanged", func(blk *IPAliasesBlock) {}, content},
+		{"keep - same value", func(blk *IPAliasesBlock) { blk.SetLabel("owner", "platform-team") }, content},
+		{"set label", func(blk *IPAliasesBlock) { blk.SetLabel("source", "helm") }, "# [4] k8s-local - Local cluster\n# @owner: platform-team\n# @source: helm\n10.0.0.1 node1"},
+		{"add label", func(blk *IPAliasesBlock) { blk.SetLabel("env", "dev") }, "# [4] k8s-local - Local cluster\n# @owner: platform-team\n# @source: kubectl\n# @env: dev\n10.0.0.1 node1"},
+		{"remove label", func(blk *IPAliasesBlock) { blk.RemoveLabel("owner") }, "# [4] k8s-local - Local cluster\n# @source: kubectl\n10.0.0.1 node1"},
+		{"rename block", func(blk *IPAliasesBlock) { blk.SetName("k8s") }, "# [4] k8s - Local cluster\n# @owner: platform-team\n# @source: kubectl\n10.0.0.1 node1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			doc, _ := Read(strings.NewReader(content))
+			tt.modify(doc.IPBlocks()[0])
+			w := &strings.Builder{}
+
+			// act
+			err := Write(w, doc, FmtKeep)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, w.String())
+
+			// labels are recognized after reading them back
+			rdoc, _ := Read(strings.NewReader(w.String()))
+			assert.Equal(t, doc.IPBlocks()[0].Labels(), rdoc.IPBlocks()[0].Labels())
+			assert.Equal(t, "Local cluster", rdoc.IPBlocks()[0].Note())
+		})
+	}
+}
+
+func Test_format_expires(t *testing.T) {
+	expires := time.Date(2026, 10, 18, 20, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		content string
+		expires time.Time
+		want    string
+	}{
+		{"set", "10.0.0.5 demo.local", expires, "10.0.0.5 demo.local  # @expires: 2026-10-18T20:00:00Z"},
+		{"set with comment", "10.0.0.5 demo.local # sales demo", expires, "10.0.0.5 demo.local  # sales demo @expires: 2026-10-18T20:00:00Z"},
+		{"replace", "10.0.0.5 demo.local # sales demo @expires: 2026-10-17T08:00:00Z", expires, "10.0.0.5 demo.local  # sales demo @expires: 2026-10-18T20:00:00Z"},
+		{"remove", "10.0.0.5 demo.local # sales demo @expires: 2026-10-17T08:00:00Z", time.Time{}, "10.0.0.5 demo.local  # sales demo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			doc, _ := Read(strings.NewReader(tt.content))
+			doc.IPBlocks()[0].AliasEntries()[0].SetExpires(tt.expires)
+			w := &strings.Builder{}
+
+			// act
+			err := Write(w, doc, FmtKeep)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, w.String())
+
+			// expiry is recognized after reading it back
+			rdoc, _ := Read(strings.NewReader(w.String()))
+			got, ok := rdoc.IPBlocks()[0].AliasEntries()[0].Expires()
+			assert.Equal(t, !tt.expires.IsZero(), ok)
+			assert.True(t, tt.expires.Equal(got))
+		})
+	}
+}
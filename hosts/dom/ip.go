@@ -1,13 +1,19 @@
 package dom
 
 import (
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/0xcfff/hostsctl/hosts/syntax"
 	"github.com/0xcfff/hostsctl/iptools"
 	"golang.org/x/exp/slices"
 )
 
+var (
+	rxEntryExpires = regexp.MustCompile(`(^|\s)@expires:\s*(\S+)`)
+)
+
 type IPAliasesEntry struct {
 	origElement syntax.Element
 	ip          string
@@ -62,6 +68,17 @@ func (blk *IPAliasesEntry) RemoveAlias(alias string) bool {
 	return changed
 }
 
+// Replaces aliases of the entry, returns true if the aliases are changed
+func (blk *IPAliasesEntry) SetAliases(aliases []string) bool {
+	if slices.Equal(blk.aliases, aliases) {
+		return false
+	}
+	blk.aliases = slices.Clone(aliases)
+	blk.origElement = nil
+	blk.changed = true
+	return true
+}
+
 func (blk *IPAliasesEntry) Note() string {
 	return blk.note
 }
@@ -74,6 +91,35 @@ func (blk *IPAliasesEntry) SetNote(comment string) {
 	}
 }
 
+// Returns expiry time recorded in the entry comment as "@expires: <RFC 3339 time>",
+// false if the comment has no expiry or the time can't be parsed
+func (blk *IPAliasesEntry) Expires() (time.Time, bool) {
+	match := rxEntryExpires.FindStringSubmatch(blk.note)
+	if match == nil {
+		return time.Time{}, false
+	}
+	expires, err := time.Parse(time.RFC3339, match[2])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expires, true
+}
+
+// Records expiry time in the entry comment replacing the existing one,
+// zero time removes the expiry from the comment
+func (blk *IPAliasesEntry) SetExpires(expires time.Time) {
+	note := strings.TrimSpace(rxEntryExpires.ReplaceAllString(blk.note, ""))
+	if !expires.IsZero() {
+		marker := "@expires: " + expires.UTC().Format(time.RFC3339)
+		if note == "" {
+			note = marker
+		} else {
+			note = note + " " + marker
+		}
+	}
+	blk.SetNote(note)
+}
+
 func (blk *IPAliasesEntry) Disabled() bool {
 	return blk.disabled
 }
@@ -85,6 +131,23 @@ func (blk *IPAliasesEntry) SetDisabled(disabled bool) {
 		blk.changed = true
 	}
 }
+
+// Returns number of the line the entry was parsed from, 0 if the entry is new or changed
+func (blk *IPAliasesEntry) Line() int {
+	if blk.origElement == nil {
+		return 0
+	}
+	return blk.origElement.OriginalLineIndex()
+}
+
+// Returns text of the line the entry was parsed from, empty string if the entry is new or changed
+func (blk *IPAliasesEntry) LineText() string {
+	if blk.origElement == nil || !blk.origElement.HasPreformattedText() {
+		return ""
+	}
+	return blk.origElement.PreformattedLineText()
+}
+
 func (blk *IPAliasesEntry) ClearFormatting() {
 	blk.origElement = nil
 	blk.changed = true
@@ -170,6 +233,10 @@ func isAliasesPlaceholder(comment *syntax.CommentLine) bool {
 	return rxPlaceholder.MatchString(comment.CommentText())
 }
 
+func isIPsBodyComment(comment *syntax.CommentLine) bool {
+	return isCommentedIPMapping(comment) || isAliasesPlaceholder(comment)
+}
+
 func NewIPAliasesEntry(ip string) *IPAliasesEntry {
 	if !iptools.IsIP(ip) {
 		panic("Specified value is not a valid IP")
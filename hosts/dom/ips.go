@@ -6,6 +6,7 @@ import (
 
 	"github.com/0xcfff/hostsctl/hosts/syntax"
 	"github.com/0xcfff/hostsctl/iotools"
+	"github.com/0xcfff/hostsctl/iptools"
 	"golang.org/x/exp/slices"
 )
 
@@ -30,6 +31,10 @@ type IPAliasesBlock struct {
 	autoId     int
 	name       string
 	note       string
+	locked     bool
+	lockedBy   string
+	labels     map[string]string
+	labelKeys  []string
 	entries    []IPAliasesBlockElement
 	changed    bool
 }
@@ -69,7 +74,7 @@ func (blk *IPAliasesBlock) SetId(id int) {
 // Returns true if real ID value is set,
 // otherwise if ID is auto generated, then returns false
 func (blk *IPAliasesBlock) IdSet() bool {
-	return blk.id >= idNotSet
+	return blk.id != idNotSet
 }
 
 func (blk *IPAliasesBlock) Name() string {
@@ -92,6 +97,93 @@ func (blk *IPAliasesBlock) SetNote(comment string) {
 	blk.changed = true
 }
 
+// Returns true if the block is marked as locked and must not be changed without confirmation
+func (blk *IPAliasesBlock) Locked() bool {
+	return blk.locked
+}
+
+// Returns who or what locked the block, empty if unknown
+func (blk *IPAliasesBlock) LockedBy() string {
+	return blk.lockedBy
+}
+
+func (blk *IPAliasesBlock) SetLocked(locked bool, by string) {
+	blk.locked = locked
+	blk.lockedBy = ""
+	if locked {
+		blk.lockedBy = by
+	}
+	blk.origHeader = nil
+	blk.changed = true
+}
+
+// Returns copy of the block metadata written in the header as "# @key: value" lines
+func (blk *IPAliasesBlock) Labels() map[string]string {
+	result := make(map[string]string, len(blk.labels))
+	for k, v := range blk.labels {
+		result[k] = v
+	}
+	return result
+}
+
+// Returns metadata keys in the order they are written in the header
+func (blk *IPAliasesBlock) LabelKeys() []string {
+	return slices.Clone(blk.labelKeys)
+}
+
+func (blk *IPAliasesBlock) Label(key string) (string, bool) {
+	value, ok := blk.labels[key]
+	return value, ok
+}
+
+// Sets metadata value, new keys are added after the existing ones
+func (blk *IPAliasesBlock) SetLabel(key string, value string) {
+	if current, ok := blk.labels[key]; ok && current == value {
+		return
+	}
+	blk.setLabel(key, value)
+	blk.origHeader = nil
+	blk.changed = true
+}
+
+func (blk *IPAliasesBlock) RemoveLabel(key string) bool {
+	if _, ok := blk.labels[key]; !ok {
+		return false
+	}
+	delete(blk.labels, key)
+	idx := slices.Index(blk.labelKeys, key)
+	blk.labelKeys = slices.Delete(blk.labelKeys, idx, idx+1)
+	blk.origHeader = nil
+	blk.changed = true
+	return true
+}
+
+func (blk *IPAliasesBlock) setLabel(key string, value string) {
+	if blk.labels == nil {
+		blk.labels = make(map[string]string)
+	}
+	if _, ok := blk.labels[key]; !ok {
+		blk.labelKeys = append(blk.labelKeys, key)
+	}
+	blk.labels[key] = value
+}
+
+// Returns true if the block or any of its entries was changed after the document was loaded
+func (blk *IPAliasesBlock) Modified() bool {
+	return blk.dirty()
+}
+
+// Returns number of the first line of the block, 0 if the block is new or changed
+func (blk *IPAliasesBlock) Line() int {
+	if len(blk.origHeader) > 0 {
+		return blk.origHeader[0].OriginalLineIndex()
+	}
+	for _, ent := range blk.AliasEntries() {
+		return ent.Line()
+	}
+	return 0
+}
+
 func (blk *IPAliasesBlock) ClearFormatting() {
 	blk.origHeader = nil
 	blk.changed = true
@@ -110,8 +202,9 @@ func (blk *IPAliasesBlock) AliasEntries() []*IPAliasesEntry {
 	return aliasEntries
 }
 
+// Returns entries with the IP, addresses written in different notations are considered equal
 func (blk *IPAliasesBlock) AliasEntriesByIP(ip string) []*IPAliasesEntry {
-	found := filterSliceByTypeAndPredicate(blk.entries, func(ent *IPAliasesEntry) bool { return ent.ip == ip })
+	found := filterSliceByTypeAndPredicate(blk.entries, func(ent *IPAliasesEntry) bool { return ent.ip == ip || iptools.EqualAddr(ent.ip, ip) })
 	return found
 }
 
@@ -130,6 +223,21 @@ func (blk *IPAliasesBlock) AliasEntriesByIPOrAlias(ipOrAlias string) []*IPAliase
 
 func (blk *IPAliasesBlock) AddEntry(entry IPAliasesBlockElement) {
 	blk.entries = append(blk.entries, entry)
+	blk.changed = true
+}
+
+// Inserts entry at the specified position, appends it if the position is out of range
+func (blk *IPAliasesBlock) InsertEntry(index int, entry IPAliasesBlockElement) {
+	if index < 0 || index > len(blk.entries) {
+		index = len(blk.entries)
+	}
+	blk.entries = slices.Insert(blk.entries, index, entry)
+	blk.changed = true
+}
+
+// Returns position of the entry in the block or -1 if the entry is not found
+func (blk *IPAliasesBlock) IndexOfEntry(entry IPAliasesBlockElement) int {
+	return slices.Index(blk.entries, entry)
 }
 
 func (blk *IPAliasesBlock) RemoveEntry(entry IPAliasesBlockElement) bool {
@@ -142,6 +250,8 @@ func (blk *IPAliasesBlock) RemoveEntry(entry IPAliasesBlockElement) bool {
 	return changed
 }
 
+// Ensures the block has a placeholder if it has no entries,
+// otherwise the block can't be recognized when the document is parsed again
 func (blk *IPAliasesBlock) normalize() bool {
 	normalized := false
 	var placeholder IPAliasesBlockElement
@@ -228,9 +338,22 @@ func parseNFillIPsBlockHeader(block *IPAliasesBlock, autoId int) {
 			}
 		}
 
+		// try extract lock marker
+		if match := rxBlockLock.FindStringSubmatchIndex(headerLine); match != nil {
+			block.locked = true
+			if match[2] >= 0 {
+				block.lockedBy = headerLine[match[2]:match[3]]
+			}
+			headerLine = headerLine[match[1]:]
+		}
+
 		var blockComment []string
 		blockComment = append(blockComment, headerLine)
 		for _, line := range block.origHeader[1:] {
+			if match := rxBlockLabel.FindStringSubmatch(line.CommentText()); match != nil {
+				block.setLabel(match[1], strings.TrimSpace(match[2]))
+				continue
+			}
 			blockComment = append(blockComment, line.CommentText())
 		}
 
@@ -20,6 +20,8 @@ const (
 var (
 	rxBlockId     = regexp.MustCompile(`^\s*\[\s*(\d+|\*)\s*\]`)
 	rxPlaceholder = regexp.MustCompile(`<<\s*placeholder\s*>>`)
+	rxBlockLabel  = regexp.MustCompile(`^@([A-Za-z0-9][A-Za-z0-9_./-]*)\s*:(.*)$`)
+	rxBlockLock   = regexp.MustCompile(`(?i)^(?:-\s*)?locked(?:\s+by\s+([^\s:|]+))?(?:\s*[-:|]\s+|\s*$)`)
 )
 
 type parserContext struct {
@@ -89,7 +91,9 @@ func (ctx *parserContext) tryContinueBlock(el syntax.Element) bool {
 		}
 		return false
 	case comments:
-		if el.Type() == syntax.IPMapping || el.Type() == syntax.Comment && isAliasesPlaceholder(el.(*syntax.CommentLine)) {
+		// a commented out mapping starts the body of a block with [id] header, so the block can begin
+		// with a disabled entry, other comments (e.g. samples of the stock hosts file) are kept as is
+		if el.Type() == syntax.IPMapping || el.Type() == syntax.Comment && ctx.isBlockBodyStart(el.(*syntax.CommentLine)) {
 			ctx.ipsList = make([]syntax.Element, 0)
 			ctx.ipsList = append(ctx.ipsList, el)
 			ctx.state = ips
@@ -107,7 +111,7 @@ func (ctx *parserContext) tryContinueBlock(el syntax.Element) bool {
 		}
 		if el.Type() == syntax.Comment {
 			c := el.(*syntax.CommentLine)
-			if isCommentedIPMapping(c) || isAliasesPlaceholder(c) {
+			if isIPsBodyComment(c) {
 				ctx.ipsList = append(ctx.ipsList, el)
 				return true
 			}
@@ -118,6 +122,14 @@ func (ctx *parserContext) tryContinueBlock(el syntax.Element) bool {
 	}
 }
 
+func (ctx *parserContext) isBlockBodyStart(comment *syntax.CommentLine) bool {
+	if isAliasesPlaceholder(comment) {
+		return true
+	}
+	hasHeader := len(ctx.commentsList) > 0 && rxBlockId.MatchString(ctx.commentsList[0].CommentText())
+	return hasHeader && isCommentedIPMapping(comment)
+}
+
 func (ctx *parserContext) finishBlock() {
 	switch ctx.state {
 	case notStarted:
@@ -40,6 +40,7 @@ func Test_parse(t *testing.T) {
 		assert.Equal(t, "system ips", b0.Note())
 		assert.Equal(t, 1, len(b0.AliasEntries()))
 		assert.Equal(t, 1, b0.Id())
+		assert.False(t, b0.IdSet())
 		assert.Equal(t, "", b0.Name())
 		assert.Equal(t, "system ips", b0.Note())
 	})
@@ -57,6 +58,7 @@ func Test_parse(t *testing.T) {
 		assert.Equal(t, "[101] proj-01 - system ips", b0.origHeader[0].CommentText())
 		assert.Equal(t, 1, len(b0.AliasEntries()))
 		assert.Equal(t, 101, b0.Id())
+		assert.True(t, b0.IdSet())
 		assert.Equal(t, "proj-01", b0.Name())
 		assert.Equal(t, "system ips", b0.Note())
 	})
@@ -80,11 +82,9 @@ unrecognized line 2`
 		assert.Equal(t, 1, len(b2.BodyElements()))
 	})
 	t.Run("named ip block + disabled IP", func(t *testing.T) {
-		// This implementation may change. Currently,
-		// the idea is to consider only actual IP
-		// or special placeholder as IPs block start.
-		// As such, if the only IP in a block is disabled,
-		// a placeholder should be added in front of it.
+		// Only actual IP or special placeholder start IPs block
+		// after a comment which is not a block header ("[id] name"),
+		// so samples in comments are not treated as disabled IPs.
 		content := `# system ips
 # 127.0.0.1 localhost`
 		syndoc, _ := syntax.Read(strings.NewReader(content))
@@ -94,6 +94,65 @@ unrecognized line 2`
 		assert.Equal(t, 1, doc.BlocksCount())
 		assert.Equal(t, Comments, doc.Blocks()[0].Type())
 	})
+	t.Run("ip block with id + disabled IP", func(t *testing.T) {
+		// commented out IP after a block header starts the block body, so no placeholder is needed
+		content := `# [3] vpn - corporate
+# 10.0.0.1 old.corp`
+		syndoc, _ := syntax.Read(strings.NewReader(content))
+		doc := parse(syndoc)
+
+		assert.Equal(t, 1, doc.BlocksCount())
+		assert.Equal(t, IPList, doc.Blocks()[0].Type())
+		b0 := doc.Blocks()[0].(*IPAliasesBlock)
+		assert.Equal(t, "vpn", b0.Name())
+		assert.Equal(t, 1, len(b0.AliasEntries()))
+		assert.True(t, b0.AliasEntries()[0].Disabled())
+	})
+	t.Run("windows default hosts file", func(t *testing.T) {
+		content := "# Copyright (c) 1993-2009 Microsoft Corp.\n" +
+			"#\n" +
+			"# This is a sample HOSTS file used by Microsoft TCP/IP for Windows.\n" +
+			"#\n" +
+			"# This file contains the mappings of IP addresses to host names. Each\n" +
+			"# entry should be kept on an individual line. The IP address should\n" +
+			"# be placed in the first column followed by the corresponding host name.\n" +
+			"# The IP address and the host name should be separated by at least one\n" +
+			"# space.\n" +
+			"#\n" +
+			"# Additionally, comments (such as these) may be inserted on individual\n" +
+			"# lines or following the machine name denoted by a '#' symbol.\n" +
+			"#\n" +
+			"# For example:\n" +
+			"#\n" +
+			"#      102.54.94.97     rhino.acme.com          # source server\n" +
+			"#       38.25.63.10     x.acme.com              # x client host\n" +
+			"\n" +
+			"# localhost name resolution is handled within DNS itself.\n" +
+			"#\t127.0.0.1       localhost\n" +
+			"#\t::1             localhost\n"
+		syndoc, _ := syntax.Read(strings.NewReader(content))
+		doc := parse(syndoc)
+
+		assert.Equal(t, 0, len(doc.IPBlocks()))
+		for _, b := range doc.Blocks() {
+			assert.NotEqual(t, IPList, b.Type())
+		}
+	})
+	t.Run("named ip block + disabled IP + IP", func(t *testing.T) {
+		content := `# [3] vpn - corporate
+# 10.0.0.1 old.corp
+10.0.0.2 new.corp`
+		syndoc, _ := syntax.Read(strings.NewReader(content))
+		doc := parse(syndoc)
+
+		assert.Equal(t, 1, doc.BlocksCount())
+		b0 := doc.Blocks()[0].(*IPAliasesBlock)
+		assert.Equal(t, 1, len(b0.origHeader))
+		assert.Equal(t, "vpn", b0.Name())
+		assert.Equal(t, 2, len(b0.AliasEntries()))
+		assert.True(t, b0.AliasEntries()[0].Disabled())
+		assert.False(t, b0.AliasEntries()[1].Disabled())
+	})
 	t.Run("empty ip block", func(t *testing.T) {
 		content := `# custom ips
 # <<placeholder>>`
@@ -110,4 +169,54 @@ unrecognized line 2`
 		assert.Equal(t, "custom ips", b0.Note())
 		assert.Equal(t, 0, len(b0.AliasEntries()))
 	})
+	t.Run("locked ip block", func(t *testing.T) {
+		tests := []struct {
+			header   string
+			name     string
+			note     string
+			locked   bool
+			lockedBy string
+		}{
+			{"# [3] corp-vpn - locked", "corp-vpn", "", true, ""},
+			{"# [3] corp-vpn - locked by ansible", "corp-vpn", "", true, "ansible"},
+			{"# [3] corp-vpn - Locked by ansible - Corporate VPN", "corp-vpn", "Corporate VPN", true, "ansible"},
+			{"# [3] corp-vpn : locked: Corporate VPN", "corp-vpn", "Corporate VPN", true, ""},
+			{"# [3] - locked by root", "", "", true, "root"},
+			{"# [3] corp-vpn - locked out users", "corp-vpn", "locked out users", false, ""},
+			{"# [3] corp-vpn - unlocked", "corp-vpn", "unlocked", false, ""},
+		}
+		for _, tt := range tests {
+			content := tt.header + "\n10.0.0.1 vpn"
+			syndoc, _ := syntax.Read(strings.NewReader(content))
+			doc := parse(syndoc)
+
+			assert.Equal(t, 1, doc.BlocksCount(), tt.header)
+			b0 := doc.Blocks()[0].(*IPAliasesBlock)
+			assert.Equal(t, 3, b0.Id(), tt.header)
+			assert.Equal(t, tt.name, b0.Name(), tt.header)
+			assert.Equal(t, tt.note, b0.Note(), tt.header)
+			assert.Equal(t, tt.locked, b0.Locked(), tt.header)
+			assert.Equal(t, tt.lockedBy, b0.LockedBy(), tt.header)
+			assert.False(t, b0.Modified(), tt.header)
+		}
+	})
+	t.Run("ip block with labels", func(t *testing.T) {
+		content := `# [4] k8s-local - Local cluster
+# nodes and services
+# @owner: platform-team
+# @source:kubectl
+# @updated: 2026-10-01T10:00:00Z
+10.0.0.1 node1`
+		syndoc, _ := syntax.Read(strings.NewReader(content))
+		doc := parse(syndoc)
+
+		assert.Equal(t, 1, doc.BlocksCount())
+		b0 := doc.Blocks()[0].(*IPAliasesBlock)
+		assert.Equal(t, 5, len(b0.origHeader))
+		assert.Equal(t, "k8s-local", b0.Name())
+		assert.Equal(t, "Local cluster\nnodes and services", b0.Note())
+		assert.Equal(t, map[string]string{"owner": "platform-team", "source": "kubectl", "updated": "2026-10-01T10:00:00Z"}, b0.Labels())
+		assert.Equal(t, []string{"owner", "source", "updated"}, b0.LabelKeys())
+		assert.False(t, b0.Modified())
+	})
 }
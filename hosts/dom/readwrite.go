@@ -27,7 +27,7 @@ func Read(r io.Reader) (*Document, error) {
 
 // Write document to a writer with specified formatting
 func Write(w io.Writer, doc *Document, fm FmtMode) error {
-	sdoc := constructSyntax(doc)
+	sdoc := constructSyntax(doc, fm)
 	sfm := fm.toSyntaxFormat()
 	// TODO: Add re-formatting logic at dom mode (remove unneeded spaces, etc)
 	syntax.Write(w, sdoc, sfm)
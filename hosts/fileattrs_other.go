@@ -0,0 +1,14 @@
+//go:build !linux && !darwin
+
+package hosts
+
+import (
+	"os"
+
+	"github.com/spf13/afero"
+)
+
+// Owner and extended attributes are not preserved on this platform
+func copyFileAttributes(fs afero.Fs, info os.FileInfo, source string, target string) error {
+	return nil
+}
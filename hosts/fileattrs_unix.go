@@ -0,0 +1,69 @@
+//go:build linux || darwin
+
+package hosts
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"syscall"
+
+	"github.com/spf13/afero"
+	"golang.org/x/sys/unix"
+)
+
+// Copies owner and extended attributes of the original file to the target file
+func copyFileAttributes(fs afero.Fs, info os.FileInfo, source string, target string) error {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		err := fs.Chown(target, int(st.Uid), int(st.Gid))
+		if err != nil {
+			return err
+		}
+	}
+
+	// extended attributes are only available for real files
+	if _, ok := fs.(*afero.OsFs); !ok {
+		return nil
+	}
+	return copyXattrs(source, target)
+}
+
+func copyXattrs(source string, target string) error {
+	size, err := unix.Listxattr(source, nil)
+	if err != nil {
+		if errors.Is(err, unix.ENOTSUP) {
+			return nil
+		}
+		return err
+	}
+	if size == 0 {
+		return nil
+	}
+
+	buff := make([]byte, size)
+	size, err = unix.Listxattr(source, buff)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range bytes.Split(buff[:size], []byte{0}) {
+		if len(name) == 0 {
+			continue
+		}
+		attr := string(name)
+		vsize, err := unix.Getxattr(source, attr, nil)
+		if err != nil {
+			return err
+		}
+		value := make([]byte, vsize)
+		vsize, err = unix.Getxattr(source, attr, value)
+		if err != nil {
+			return err
+		}
+		err = unix.Setxattr(target, attr, value[:vsize], 0)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
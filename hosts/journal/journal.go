@@ -0,0 +1,263 @@
+package journal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	// Default max number of changes kept in the journal
+	DefaultLimit = 50
+
+	indexFile = "index.json"
+	beforeExt = ".before"
+	afterExt  = ".after"
+)
+
+var (
+	ErrNothingToUndo    = errors.New("nothing to undo")
+	ErrNothingToRedo    = errors.New("nothing to redo")
+	ErrChecksumMismatch = errors.New("journal content checksum mismatch")
+)
+
+// State of the journaled change
+type State string
+
+const (
+	Applied State = "applied"
+	Undone  State = "undone"
+)
+
+// Journaled hosts file change
+type Entry struct {
+	Seq     int       `json:"seq"     yaml:"seq"`
+	Time    time.Time `json:"time"    yaml:"time"`
+	Command string    `json:"command" yaml:"command"`
+	Before  string    `json:"before"  yaml:"before"`
+	After   string    `json:"after"   yaml:"after"`
+	State   State     `json:"state"   yaml:"state"`
+}
+
+// Persisted journal state
+type index struct {
+	Entries []*Entry `json:"entries"`
+}
+
+// Log of hosts file changes which allows to undo and redo them
+type Journal struct {
+	dir   string
+	fs    afero.Fs
+	limit int
+	now   func() time.Time
+}
+
+func NewJournal(dir string, fs afero.Fs) *Journal {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+	return &Journal{
+		dir:   dir,
+		fs:    fs,
+		limit: DefaultLimit,
+		now:   time.Now,
+	}
+}
+
+func (j *Journal) Dir() string {
+	return j.dir
+}
+
+// Sets max number of changes kept in the journal, older changes are discarded
+func (j *Journal) SetLimit(limit int) {
+	j.limit = limit
+}
+
+// Records the change, changes undone before are discarded as they can't be redone anymore
+func (j *Journal) Record(command string, before []byte, after []byte) (*Entry, error) {
+	idx, err := j.load()
+	if err != nil {
+		return nil, err
+	}
+
+	err = j.fs.MkdirAll(j.dir, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("can't create journal directory %s, %w", j.dir, err)
+	}
+
+	applied := make([]*Entry, 0, len(idx.Entries)+1)
+	for _, e := range idx.Entries {
+		if e.State == Undone {
+			j.removeContent(e)
+			continue
+		}
+		applied = append(applied, e)
+	}
+
+	seq := 1
+	if len(idx.Entries) > 0 {
+		seq = idx.Entries[len(idx.Entries)-1].Seq + 1
+	}
+	entry := &Entry{
+		Seq:     seq,
+		Time:    j.now().UTC(),
+		Command: command,
+		Before:  checksum(before),
+		After:   checksum(after),
+		State:   Applied,
+	}
+
+	err = afero.WriteFile(j.fs, j.contentPath(entry, beforeExt), before, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("can't write journal %s, %w", j.dir, err)
+	}
+	err = afero.WriteFile(j.fs, j.contentPath(entry, afterExt), after, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("can't write journal %s, %w", j.dir, err)
+	}
+	applied = append(applied, entry)
+
+	if j.limit > 0 && len(applied) > j.limit {
+		for _, e := range applied[:len(applied)-j.limit] {
+			j.removeContent(e)
+		}
+		applied = applied[len(applied)-j.limit:]
+	}
+
+	idx.Entries = applied
+	return entry, j.save(idx)
+}
+
+// Returns journaled changes ordered from the oldest to the newest
+func (j *Journal) History() ([]*Entry, error) {
+	idx, err := j.load()
+	if err != nil {
+		return nil, err
+	}
+	return idx.Entries, nil
+}
+
+// Returns the most recent applied change
+func (j *Journal) Undoable() (*Entry, error) {
+	idx, err := j.load()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(idx.Entries) - 1; i >= 0; i-- {
+		if idx.Entries[i].State == Applied {
+			return idx.Entries[i], nil
+		}
+	}
+	return nil, ErrNothingToUndo
+}
+
+// Returns the oldest undone change
+func (j *Journal) Redoable() (*Entry, error) {
+	idx, err := j.load()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range idx.Entries {
+		if e.State == Undone {
+			return e, nil
+		}
+	}
+	return nil, ErrNothingToRedo
+}
+
+// Returns hosts file content before the change
+func (j *Journal) ContentBefore(entry *Entry) ([]byte, error) {
+	return j.readContent(entry, beforeExt, entry.Before)
+}
+
+// Returns hosts file content after the change
+func (j *Journal) ContentAfter(entry *Entry) ([]byte, error) {
+	return j.readContent(entry, afterExt, entry.After)
+}
+
+// Changes state of the journaled change
+func (j *Journal) SetState(entry *Entry, state State) error {
+	idx, err := j.load()
+	if err != nil {
+		return err
+	}
+	for _, e := range idx.Entries {
+		if e.Seq == entry.Seq {
+			e.State = state
+			entry.State = state
+			return j.save(idx)
+		}
+	}
+	return fmt.Errorf("journal entry %d not found", entry.Seq)
+}
+
+// Returns true if the data is the hosts file content after the change
+func (e *Entry) IsAfter(data []byte) bool {
+	return e.After == checksum(data)
+}
+
+// Returns true if the data is the hosts file content before the change
+func (e *Entry) IsBefore(data []byte) bool {
+	return e.Before == checksum(data)
+}
+
+func (j *Journal) load() (*index, error) {
+	idx := &index{Entries: make([]*Entry, 0)}
+	data, err := afero.ReadFile(j.fs, filepath.Join(j.dir, indexFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return idx, nil
+		}
+		return nil, fmt.Errorf("can't read journal %s, %w", j.dir, err)
+	}
+	err = json.Unmarshal(data, idx)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse journal %s, %w", j.dir, err)
+	}
+	return idx, nil
+}
+
+func (j *Journal) save(idx *index) error {
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = afero.WriteFile(j.fs, filepath.Join(j.dir, indexFile), data, 0o600)
+	if err != nil {
+		return fmt.Errorf("can't write journal %s, %w", j.dir, err)
+	}
+	return nil
+}
+
+func (j *Journal) readContent(entry *Entry, ext string, sum string) ([]byte, error) {
+	data, err := afero.ReadFile(j.fs, j.contentPath(entry, ext))
+	if err != nil {
+		return nil, fmt.Errorf("can't read journal entry %d, %w", entry.Seq, err)
+	}
+	if checksum(data) != sum {
+		return nil, fmt.Errorf("entry: %d; %w", entry.Seq, ErrChecksumMismatch)
+	}
+	return data, nil
+}
+
+func (j *Journal) removeContent(entry *Entry) {
+	j.fs.Remove(j.contentPath(entry, beforeExt))
+	j.fs.Remove(j.contentPath(entry, afterExt))
+}
+
+func (j *Journal) contentPath(entry *Entry, ext string) string {
+	return filepath.Join(j.dir, strconv.Itoa(entry.Seq)+ext)
+}
+
+func checksum(data []byte) string {
+	h := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(h[:])
+}
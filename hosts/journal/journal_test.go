@@ -0,0 +1,96 @@
+package journal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestJournal() *Journal {
+	j := NewJournal("/etc/hosts.backups/journal", afero.NewMemMapFs())
+	j.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return j
+}
+
+func seqs(t *testing.T, j *Journal) []int {
+	entries, err := j.History()
+	assert.NoError(t, err)
+	result := make([]int, 0)
+	for _, e := range entries {
+		result = append(result, e.Seq)
+	}
+	return result
+}
+
+func TestJournal_RecordAndUndo(t *testing.T) {
+	j := newTestJournal()
+
+	_, err := j.Undoable()
+	assert.ErrorIs(t, err, ErrNothingToUndo)
+
+	j.Record("hostsctl alias add 1.1.1.1 a", []byte("v0"), []byte("v1"))
+	e2, _ := j.Record("hostsctl alias add 2.2.2.2 b", []byte("v1"), []byte("v2"))
+
+	undo, err := j.Undoable()
+	assert.NoError(t, err)
+	assert.Equal(t, e2.Seq, undo.Seq)
+	assert.Equal(t, "hostsctl alias add 2.2.2.2 b", undo.Command)
+	assert.True(t, undo.IsAfter([]byte("v2")))
+	assert.False(t, undo.IsAfter([]byte("v1")))
+	before, err := j.ContentBefore(undo)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", string(before))
+
+	err = j.SetState(undo, Undone)
+	assert.NoError(t, err)
+
+	redo, err := j.Redoable()
+	assert.NoError(t, err)
+	assert.Equal(t, e2.Seq, redo.Seq)
+	after, err := j.ContentAfter(redo)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", string(after))
+
+	undo, _ = j.Undoable()
+	assert.Equal(t, 1, undo.Seq)
+}
+
+func TestJournal_RecordDiscardsUndone(t *testing.T) {
+	j := newTestJournal()
+	j.Record("c1", []byte("v0"), []byte("v1"))
+	e2, _ := j.Record("c2", []byte("v1"), []byte("v2"))
+	j.SetState(e2, Undone)
+
+	j.Record("c3", []byte("v1"), []byte("v3"))
+
+	assert.Equal(t, []int{1, 3}, seqs(t, j))
+	_, err := j.Redoable()
+	assert.ErrorIs(t, err, ErrNothingToRedo)
+	_, err = j.ContentAfter(e2)
+	assert.Error(t, err)
+}
+
+func TestJournal_Limit(t *testing.T) {
+	j := newTestJournal()
+	j.SetLimit(2)
+
+	first, _ := j.Record("c1", []byte("v0"), []byte("v1"))
+	j.Record("c2", []byte("v1"), []byte("v2"))
+	j.Record("c3", []byte("v2"), []byte("v3"))
+
+	assert.Equal(t, []int{2, 3}, seqs(t, j))
+	_, err := j.ContentBefore(first)
+	assert.Error(t, err)
+}
+
+func TestJournal_ContentChecksum(t *testing.T) {
+	j := newTestJournal()
+	e, _ := j.Record("c1", []byte("v0"), []byte("v1"))
+	afero.WriteFile(j.fs, j.contentPath(e, beforeExt), []byte("tampered"), 0o600)
+
+	_, err := j.ContentBefore(e)
+
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package lint
+
+const (
+	// Line buffer size of BSD derived and older glibc resolvers
+	MaxLineLength = 1024
+	// Max number of aliases returned by gethostbyname in BSD derived and older glibc resolvers
+	MaxAliasesPerLine = 35
+)
@@ -0,0 +1,8 @@
+package lint
+
+const (
+	// Windows resolver ignores the rest of longer lines
+	MaxLineLength = 256
+	// Windows resolver ignores aliases beyond this number
+	MaxAliasesPerLine = 9
+)
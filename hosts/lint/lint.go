@@ -0,0 +1,289 @@
+package lint
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/0xcfff/hostsctl/hosts/syntax"
+	"github.com/0xcfff/hostsctl/iptools"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	rxIPv4Like = regexp.MustCompile(`^\d+\.\d+\.\d+\.\d+$`)
+	rxIPv6Like = regexp.MustCompile(`^[\da-fA-F]*:[\da-fA-F]*:[\da-fA-F:.]*(%\S+)?$`)
+)
+
+// Severity of the found problem
+type Severity string
+
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+)
+
+// Identifier of the check which found the problem
+type RuleId string
+
+const (
+	InvalidIP          RuleId = "invalid-ip"
+	InvalidHostname    RuleId = "invalid-hostname"
+	DuplicateAlias     RuleId = "duplicate-alias"
+	ConflictingIP      RuleId = "conflicting-ip"
+	UnrecognizedLine   RuleId = "unrecognized-line"
+	DuplicateBlockId   RuleId = "duplicate-block-id"
+	DuplicateBlockName RuleId = "duplicate-block-name"
+	LineTooLong        RuleId = "line-too-long"
+	TooManyAliases     RuleId = "too-many-aliases"
+)
+
+// Description of a check
+type Rule struct {
+	Id          RuleId
+	Severity    Severity
+	Description string
+}
+
+// All checks performed by the linter
+var Rules = []Rule{
+	{InvalidIP, Error, "IP address can't be parsed"},
+	{InvalidHostname, Error, "Alias is not a valid RFC 1123 host name"},
+	{DuplicateAlias, Warning, "Alias is mapped to the same IP more than once"},
+	{ConflictingIP, Warning, "Alias is mapped to different IPs of the same address family"},
+	{UnrecognizedLine, Warning, "Line is neither an IP mapping, a comment nor a blank line"},
+	{DuplicateBlockId, Error, "Block id is used by more than one block"},
+	{DuplicateBlockName, Warning, "Block name is used by more than one block"},
+	{LineTooLong, Warning, fmt.Sprintf("Line is longer than %d characters supported on this platform", MaxLineLength)},
+	{TooManyAliases, Warning, fmt.Sprintf("Line has more than %d aliases supported on this platform", MaxAliasesPerLine)},
+}
+
+// Problem found in the hosts file
+type Diagnostic struct {
+	Line     int
+	Column   int
+	Severity Severity
+	Rule     RuleId
+	Message  string
+}
+
+func (d *Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s [%s]", d.Line, d.Column, d.Severity, d.Message, d.Rule)
+}
+
+// Checks the document and returns found problems ordered by position
+func Lint(doc *syntax.Document) []*Diagnostic {
+	l := &linter{diagnostics: make([]*Diagnostic, 0)}
+
+	l.checkLines(doc)
+	ddoc := dom.NewDocument(doc)
+	l.checkBlocks(ddoc)
+	l.checkEntries(ddoc)
+	l.checkAliases(ddoc)
+
+	slices.SortStableFunc(l.diagnostics, func(a, b *Diagnostic) bool {
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return l.diagnostics
+}
+
+// Returns true if any of the diagnostics is an error
+func HasErrors(diagnostics []*Diagnostic) bool {
+	return slices.IndexFunc(diagnostics, func(d *Diagnostic) bool { return d.Severity == Error }) >= 0
+}
+
+type linter struct {
+	diagnostics []*Diagnostic
+}
+
+func (l *linter) report(line int, column int, severity Severity, rule RuleId, format string, args ...any) {
+	l.diagnostics = append(l.diagnostics, &Diagnostic{
+		Line:     line,
+		Column:   column,
+		Severity: severity,
+		Rule:     rule,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Checks raw lines of the document
+func (l *linter) checkLines(doc *syntax.Document) {
+	for _, el := range doc.Elements() {
+		line := el.OriginalLineIndex()
+		text := ""
+		if el.HasPreformattedText() {
+			text = el.PreformattedLineText()
+		}
+
+		if len(text) > MaxLineLength {
+			l.report(line, MaxLineLength+1, Warning, LineTooLong, "line is %d characters long, max is %d", len(text), MaxLineLength)
+		}
+
+		switch el.Type() {
+		case syntax.Unknown:
+			if !l.checkMalformedEntry(line, text, text, Error) {
+				l.report(line, firstNonSpaceColumn(text), Warning, UnrecognizedLine, "line is not recognized: %s", strings.TrimSpace(text))
+			}
+		case syntax.Comment:
+			// disabled entries with wrong IPs are not recognized as entries
+			l.checkMalformedEntry(line, text, el.(*syntax.CommentLine).CommentText(), Warning)
+		case syntax.IPMapping:
+			ip := el.(*syntax.IPMappingLine)
+			if cnt := len(ip.DomainNames()); cnt > MaxAliasesPerLine {
+				l.report(line, 1, Warning, TooManyAliases, "line has %d aliases, max is %d", cnt, MaxAliasesPerLine)
+			}
+		}
+	}
+}
+
+// Reports IP and aliases of the mapping if it looks like an entry, but its IP can't be parsed,
+// returns false if the mapping doesn't look like an entry
+func (l *linter) checkMalformedEntry(line int, text string, mapping string, severity Severity) bool {
+	if idx := strings.Index(mapping, "#"); idx >= 0 {
+		mapping = mapping[:idx]
+	}
+	fields := strings.Fields(mapping)
+	if len(fields) < 2 || iptools.IsIP(fields[0]) {
+		return false
+	}
+	if !rxIPv4Like.MatchString(fields[0]) && !rxIPv6Like.MatchString(fields[0]) {
+		return false
+	}
+
+	columns := fieldColumns(text)
+	l.report(line, columns.find(fields[0]), severity, InvalidIP, "invalid IP address %s", fields[0])
+	for _, alias := range fields[1:] {
+		if !iptools.IsHostname(alias) {
+			l.report(line, columns.find(alias), severity, InvalidHostname, "invalid host name %s", alias)
+		}
+	}
+	return true
+}
+
+// Checks uniqueness of block identifiers
+func (l *linter) checkBlocks(doc *dom.Document) {
+	ids := make(map[int]*dom.IPAliasesBlock)
+	names := make(map[string]*dom.IPAliasesBlock)
+	for _, block := range doc.IPBlocks() {
+		if first, ok := ids[block.Id()]; ok {
+			l.report(block.Line(), 1, Error, DuplicateBlockId, "block id %d is already used by the block at line %d", block.Id(), first.Line())
+		} else {
+			ids[block.Id()] = block
+		}
+
+		if block.Name() == "" {
+			continue
+		}
+		name := strings.ToLower(block.Name())
+		if first, ok := names[name]; ok {
+			l.report(block.Line(), 1, Warning, DuplicateBlockName, "block name %s is already used by the block at line %d", block.Name(), first.Line())
+		} else {
+			names[name] = block
+		}
+	}
+}
+
+// Checks IPs and aliases of every entry, problems of disabled entries are reported as warnings
+func (l *linter) checkEntries(doc *dom.Document) {
+	for _, block := range doc.IPBlocks() {
+		for _, ent := range block.AliasEntries() {
+			severity := Error
+			if ent.Disabled() {
+				severity = Warning
+			}
+			columns := fieldColumns(ent.LineText())
+
+			for _, alias := range ent.Aliases() {
+				if !iptools.IsHostname(alias) {
+					l.report(ent.Line(), columns.find(alias), severity, InvalidHostname, "invalid host name %s", alias)
+				}
+			}
+		}
+	}
+}
+
+// Position of the first occurrence of an alias
+type aliasPosition struct {
+	ip     netip.Addr
+	line   int
+	column int
+}
+
+// Checks enabled aliases across all blocks for duplicates and conflicts
+func (l *linter) checkAliases(doc *dom.Document) {
+	seen := make(map[string][]aliasPosition)
+	for _, block := range doc.IPBlocks() {
+		for _, ent := range block.AliasEntries() {
+			if ent.Disabled() {
+				continue
+			}
+			ip, err := netip.ParseAddr(ent.IP())
+			if err != nil {
+				continue
+			}
+			ip = ip.Unmap().WithZone("")
+			columns := fieldColumns(ent.LineText())
+			for _, alias := range ent.Aliases() {
+				key := strings.ToLower(alias)
+				pos := aliasPosition{ip: ip, line: ent.Line(), column: columns.find(alias)}
+				prev := seen[key]
+
+				idx := slices.IndexFunc(prev, func(p aliasPosition) bool { return p.ip == ip })
+				if idx >= 0 {
+					l.report(pos.line, pos.column, Warning, DuplicateAlias, "alias %s is already mapped to %s at line %d", alias, ent.IP(), prev[idx].line)
+					continue
+				}
+				idx = slices.IndexFunc(prev, func(p aliasPosition) bool { return p.ip.Is4() == ip.Is4() })
+				if idx >= 0 {
+					l.report(pos.line, pos.column, Warning, ConflictingIP, "alias %s is mapped to %s, but it is already mapped to %s at line %d", alias, ent.IP(), prev[idx].ip, prev[idx].line)
+				}
+				seen[key] = append(prev, pos)
+			}
+		}
+	}
+}
+
+// Whitespace separated fields of a line and their columns
+type lineFields struct {
+	fields  []string
+	columns []int
+	next    int
+}
+
+func fieldColumns(text string) *lineFields {
+	lf := &lineFields{}
+	start := -1
+	for i, c := range text + " " {
+		if unicode.IsSpace(c) {
+			if start >= 0 {
+				lf.fields = append(lf.fields, text[start:i])
+				lf.columns = append(lf.columns, start+1)
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	return lf
+}
+
+// Returns column of the next field equal to the value, 1 if the field is not found
+func (lf *lineFields) find(value string) int {
+	for i := lf.next; i < len(lf.fields); i++ {
+		if strings.TrimLeft(lf.fields[i], "#") == value {
+			lf.next = i + 1
+			return lf.columns[i] + len(lf.fields[i]) - len(value)
+		}
+	}
+	return 1
+}
+
+func firstNonSpaceColumn(text string) int {
+	return len(text) - len(strings.TrimLeftFunc(text, unicode.IsSpace)) + 1
+}
@@ -0,0 +1,72 @@
+package lint
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/0xcfff/hostsctl/hosts/syntax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	const base = "127.0.0.1 localhost\n::1 localhost ip6-localhost\n\n# [2] pet-prj1 - My project\n10.0.0.1 cats.example.org\n"
+
+	tests := []struct {
+		name  string
+		hosts string
+		want  []string
+	}{
+		{"valid", base, []string{}},
+		{"invalid ip", base + "999.1.1.1 dogs.example.org\n", []string{"6:1: error: invalid IP address 999.1.1.1 [invalid-ip]"}},
+		{"invalid ip of disabled entry", base + "# 999.1.1.1 dogs.example.org\n", []string{"6:3: warning: invalid IP address 999.1.1.1 [invalid-ip]"}},
+		{"invalid ip and hostname", base + "10.0.0.999 dogs_example # comment\n", []string{"6:1: error: invalid IP address 10.0.0.999 [invalid-ip]", "6:12: error: invalid host name dogs_example [invalid-hostname]"}},
+		{"invalid ipv6 of disabled entry", base + "#fe80::1::2 dogs.example.org\n", []string{"6:2: warning: invalid IP address fe80::1::2 [invalid-ip]"}},
+		{"ipv4-mapped and zone", base + "::ffff:10.0.0.2 dogs.example.org\nfe80::1%eth0 router.local\n", []string{}},
+		{"comment with time", base + "# 10:30 maintenance window\n", []string{}},
+		{"invalid hostname", base + "10.0.0.2  dogs.example.org my_dogs\n", []string{"6:28: error: invalid host name my_dogs [invalid-hostname]"}},
+		{"duplicate alias", base + "10.0.0.1 cats.example.org\n", []string{"6:10: warning: alias cats.example.org is already mapped to 10.0.0.1 at line 5 [duplicate-alias]"}},
+		{"duplicate alias ignoring case", base + "10.0.0.1 Cats.Example.org\n", []string{"6:10: warning: alias Cats.Example.org is already mapped to 10.0.0.1 at line 5 [duplicate-alias]"}},
+		{"duplicate disabled alias", base + "# 10.0.0.1 cats.example.org\n", []string{}},
+		{"conflicting ip", base + "10.0.0.2 cats.example.org\n", []string{"6:10: warning: alias cats.example.org is mapped to 10.0.0.2, but it is already mapped to 10.0.0.1 at line 5 [conflicting-ip]"}},
+		{"unrecognized line", base + "  some text\n", []string{"6:3: warning: line is not recognized: some text [unrecognized-line]"}},
+		{"duplicate block id", base + "\n# [2] pet-prj2\n10.0.1.1 dogs.example.org\n", []string{"7:1: error: block id 2 is already used by the block at line 4 [duplicate-block-id]"}},
+		{"duplicate block name", base + "\n# [3] pet-prj1\n10.0.1.1 dogs.example.org\n", []string{"7:1: warning: block name pet-prj1 is already used by the block at line 4 [duplicate-block-name]"}},
+		{"line too long", base + "10.0.0.2 " + strings.Repeat("a", MaxLineLength) + "\n", []string{
+			"6:10: error: invalid host name " + strings.Repeat("a", MaxLineLength) + " [invalid-hostname]",
+			"6:" + strconv.Itoa(MaxLineLength+1) + ": warning: line is " + strconv.Itoa(MaxLineLength+9) + " characters long, max is " + strconv.Itoa(MaxLineLength) + " [line-too-long]",
+		}},
+		{"too many aliases", base + "10.0.0.2 " + hostnames(MaxAliasesPerLine+1) + "\n", []string{
+			"6:1: warning: line has " + strconv.Itoa(MaxAliasesPerLine+1) + " aliases, max is " + strconv.Itoa(MaxAliasesPerLine) + " [too-many-aliases]",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			doc, _ := syntax.Read(strings.NewReader(tt.hosts))
+
+			// act
+			diagnostics := Lint(doc)
+
+			// assert
+			got := make([]string, 0)
+			for _, d := range diagnostics {
+				got = append(got, d.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHasErrors(t *testing.T) {
+	assert.False(t, HasErrors([]*Diagnostic{{Severity: Warning}}))
+	assert.True(t, HasErrors([]*Diagnostic{{Severity: Warning}, {Severity: Error}}))
+}
+
+func hostnames(count int) string {
+	names := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		names = append(names, "node"+strconv.Itoa(i))
+	}
+	return strings.Join(names, " ")
+}
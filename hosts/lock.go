@@ -0,0 +1,80 @@
+package hosts
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	// Interval between attempts to acquire hosts file lock
+	lockRetryInterval = 50 * time.Millisecond
+)
+
+var (
+	ErrLockTimeout = errors.New("timeout waiting for hosts file lock")
+)
+
+// Acquires exclusive advisory lock on a sidecar lock file next to the hosts file.
+// The lock is expected to be held for the whole Load -> modify -> Save cycle.
+// Locking is only supported for files on the OS filesystem, for other
+// filesystems the call succeeds without locking anything.
+func (src *Source) Lock(timeout time.Duration) error {
+	if src.lockFile != nil {
+		return nil
+	}
+	if _, ok := src.fs.(*afero.OsFs); !ok {
+		return nil
+	}
+
+	lockPath := src.LockPath()
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return fmt.Errorf("can't open lock file %s, %w", lockPath, err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		locked, err := tryLockFile(f)
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("can't lock %s, %w", lockPath, err)
+		}
+		if locked {
+			break
+		}
+		if !time.Now().Before(deadline) {
+			f.Close()
+			return fmt.Errorf("%s is locked by another process for more than %v; %w", lockPath, timeout, ErrLockTimeout)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+
+	src.lockFile = f
+	return nil
+}
+
+// Releases the lock acquired by Lock
+func (src *Source) Unlock() error {
+	if src.lockFile == nil {
+		return nil
+	}
+	f := src.lockFile
+	src.lockFile = nil
+
+	err := unlockFile(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Returns path of the sidecar lock file
+func (src *Source) LockPath() string {
+	dir, name := filepath.Split(src.etcHostsPath)
+	return filepath.Join(dir, fmt.Sprintf(".%s.lock", name))
+}
@@ -0,0 +1,14 @@
+//go:build !linux && !darwin && !windows
+
+package hosts
+
+import "os"
+
+// File locking is not supported on this platform
+func tryLockFile(f *os.File) (bool, error) {
+	return true, nil
+}
+
+func unlockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,50 @@
+package hosts
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSource_Lock(t *testing.T) {
+	t.Run("locked by other source - timeout", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "hosts")
+		first := NewSource(path, afero.NewOsFs())
+		second := NewSource(path, afero.NewOsFs())
+
+		err := first.Lock(time.Second)
+		assert.NoError(t, err)
+		defer first.Unlock()
+
+		err = second.Lock(100 * time.Millisecond)
+
+		assert.ErrorIs(t, err, ErrLockTimeout)
+	})
+	t.Run("unlocked by other source - succeeds", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "hosts")
+		first := NewSource(path, afero.NewOsFs())
+		second := NewSource(path, afero.NewOsFs())
+
+		err := first.Lock(time.Second)
+		assert.NoError(t, err)
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			first.Unlock()
+		}()
+
+		err = second.Lock(5 * time.Second)
+
+		assert.NoError(t, err)
+		assert.NoError(t, second.Unlock())
+	})
+	t.Run("lock file path", func(t *testing.T) {
+		src := NewSource("/etc/hosts", afero.NewMemMapFs())
+
+		assert.Equal(t, "/etc/.hosts.lock", src.LockPath())
+		assert.NoError(t, src.Lock(time.Second))
+		assert.NoError(t, src.Unlock())
+	})
+}
@@ -0,0 +1,85 @@
+package resolve
+
+import (
+	"strings"
+
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"golang.org/x/exp/slices"
+)
+
+type ConflictKind string
+
+const (
+	// alias is mapped to the same IP more than once
+	Duplicate ConflictKind = "duplicate"
+	// alias is mapped to different IPs of the same family in one block
+	Conflict ConflictKind = "conflict"
+	// alias is mapped to a different IP in an earlier block, the entry is never used
+	Shadowed ConflictKind = "shadowed"
+)
+
+// Alias occurrence which is not used because the winner occurrence is resolved first
+type AliasConflict struct {
+	Kind       ConflictKind
+	Occurrence *Occurrence
+	Winner     *Occurrence
+}
+
+// Finds aliases mapped more than once per address family, names are compared case insensitively
+func FindConflicts(doc *dom.Document) []*AliasConflict {
+	type key struct {
+		name   string
+		family Family
+	}
+	winners := make(map[key]*Occurrence)
+	result := make([]*AliasConflict, 0)
+	for _, occ := range Occurrences(doc) {
+		k := key{strings.ToLower(occ.Alias), occ.Family()}
+		winner, ok := winners[k]
+		if !ok {
+			winners[k] = occ
+			continue
+		}
+
+		kind := Shadowed
+		if occ.Addr == winner.Addr {
+			kind = Duplicate
+		} else if occ.Block == winner.Block {
+			kind = Conflict
+		}
+		result = append(result, &AliasConflict{Kind: kind, Occurrence: occ, Winner: winner})
+	}
+	return result
+}
+
+// Removes redundant aliases of duplicate conflicts, entries left without aliases are removed,
+// returns number of removed aliases
+func RemoveDuplicates(conflicts []*AliasConflict) int {
+	drop := make(map[*dom.IPAliasesEntry][]int)
+	blocks := make(map[*dom.IPAliasesEntry]*dom.IPAliasesBlock)
+	removed := 0
+	for _, c := range conflicts {
+		if c.Kind != Duplicate {
+			continue
+		}
+		occ := c.Occurrence
+		drop[occ.Entry] = append(drop[occ.Entry], occ.Index)
+		blocks[occ.Entry] = occ.Block
+		removed++
+	}
+
+	for ent, indexes := range drop {
+		aliases := make([]string, 0)
+		for i, alias := range ent.Aliases() {
+			if !slices.Contains(indexes, i) {
+				aliases = append(aliases, alias)
+			}
+		}
+		if len(aliases) == 0 {
+			blocks[ent].RemoveEntry(ent)
+		} else {
+			ent.SetAliases(aliases)
+		}
+	}
+	return removed
+}
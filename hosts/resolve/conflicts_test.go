@@ -0,0 +1,61 @@
+package resolve
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindConflicts(t *testing.T) {
+	const base = "127.0.0.1 localhost\n::1 localhost ip6-localhost\n\n# [2] pet-prj1 - My project\n10.0.0.1 cats.example.org\n"
+
+	tests := []struct {
+		name  string
+		hosts string
+		want  []string
+	}{
+		{"no conflicts", base, []string{}},
+		{"duplicate", base + "10.0.0.1 cats.example.org\n", []string{"duplicate cats.example.org 10.0.0.1 winner 10.0.0.1"}},
+		{"duplicate ignoring case", base + "10.0.0.1 Cats.Example.org\n", []string{"duplicate Cats.Example.org 10.0.0.1 winner 10.0.0.1"}},
+		{"duplicate in one line", base + "10.0.0.2 dogs dogs\n", []string{"duplicate dogs 10.0.0.2 winner 10.0.0.2"}},
+		{"conflict", base + "10.0.0.2 cats.example.org\n", []string{"conflict cats.example.org 10.0.0.2 winner 10.0.0.1"}},
+		{"shadowed", base + "\n# [3] pet-prj2\n10.0.0.2 cats.example.org\n", []string{"shadowed cats.example.org 10.0.0.2 winner 10.0.0.1"}},
+		{"other family", base + "fd00::1 cats.example.org\n", []string{}},
+		{"disabled", base + "# 10.0.0.2 cats.example.org\n", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			doc, _ := dom.Read(strings.NewReader(tt.hosts))
+
+			// act
+			conflicts := FindConflicts(doc)
+
+			// assert
+			got := make([]string, 0)
+			for _, c := range conflicts {
+				got = append(got, fmt.Sprintf("%s %s %s winner %s", c.Kind, c.Occurrence.Alias, c.Occurrence.Entry.IP(), c.Winner.Entry.IP()))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRemoveDuplicates(t *testing.T) {
+	// arrange
+	hosts := "10.0.0.1 cats.example.org\n10.0.0.2 dogs dogs birds\n10.0.0.1 cats.example.org\n10.0.0.3 cats.example.org\n"
+	doc, _ := dom.Read(strings.NewReader(hosts))
+
+	// act
+	removed := RemoveDuplicates(FindConflicts(doc))
+
+	// assert
+	buff := &bytes.Buffer{}
+	dom.Write(buff, doc, dom.FmtKeep)
+	assert.Equal(t, 2, removed)
+	assert.Equal(t, "10.0.0.1 cats.example.org\n10.0.0.2 dogs birds\n10.0.0.3 cats.example.org\n", buff.String())
+}
@@ -0,0 +1,99 @@
+package resolve
+
+import (
+	"net/netip"
+	"strings"
+
+	"github.com/0xcfff/hostsctl/hosts/dom"
+)
+
+// Address family of an alias, a name can be resolved to one address of each family
+type Family int
+
+const (
+	IPv4 Family = 4
+	IPv6 Family = 6
+)
+
+func (f Family) String() string {
+	if f == IPv4 {
+		return "ipv4"
+	}
+	return "ipv6"
+}
+
+func FamilyOf(addr netip.Addr) Family {
+	if addr.Unmap().Is4() {
+		return IPv4
+	}
+	return IPv6
+}
+
+// Alias found in an enabled entry
+type Occurrence struct {
+	Block *dom.IPAliasesBlock
+	Entry *dom.IPAliasesEntry
+	Alias string
+	// position of the alias in the entry aliases
+	Index int
+	// IPv4-mapped addresses are unmapped, zones are dropped
+	Addr netip.Addr
+}
+
+func (o *Occurrence) Family() Family {
+	return FamilyOf(o.Addr)
+}
+
+// Returns aliases of enabled entries in the order they are resolved,
+// entries with invalid IPs are skipped
+func Occurrences(doc *dom.Document) []*Occurrence {
+	result := make([]*Occurrence, 0)
+	for _, block := range doc.IPBlocks() {
+		for _, ent := range block.AliasEntries() {
+			if ent.Disabled() {
+				continue
+			}
+			addr, err := netip.ParseAddr(ent.IP())
+			if err != nil {
+				continue
+			}
+			addr = addr.Unmap().WithZone("")
+			for i, alias := range ent.Aliases() {
+				result = append(result, &Occurrence{Block: block, Entry: ent, Alias: alias, Index: i, Addr: addr})
+			}
+		}
+	}
+	return result
+}
+
+// Address a name resolves to in one address family
+type Answer struct {
+	Family Family
+	Winner *Occurrence
+	// later occurrences of the name in the same family, they are never used
+	Shadowed []*Occurrence
+}
+
+// Emulates hosts file lookup: the first enabled entry with the name wins in each address family,
+// names are compared case insensitively. Returns IPv4 answer first, nil if the name is not found
+func Resolve(doc *dom.Document, name string) []*Answer {
+	answers := make(map[Family]*Answer)
+	for _, occ := range Occurrences(doc) {
+		if !strings.EqualFold(occ.Alias, name) {
+			continue
+		}
+		if a, ok := answers[occ.Family()]; ok {
+			a.Shadowed = append(a.Shadowed, occ)
+		} else {
+			answers[occ.Family()] = &Answer{Family: occ.Family(), Winner: occ, Shadowed: make([]*Occurrence, 0)}
+		}
+	}
+
+	var result []*Answer
+	for _, f := range []Family{IPv4, IPv6} {
+		if a, ok := answers[f]; ok {
+			result = append(result, a)
+		}
+	}
+	return result
+}
@@ -0,0 +1,49 @@
+package resolve
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	const hosts = "127.0.0.1 localhost\n::1 localhost ip6-localhost\n\n" +
+		"# [2] pet-prj1 - My project\n# 10.0.0.9 cats.example.org\n10.0.0.1 Cats.Example.org\n10.0.0.2 cats.example.org\n\n" +
+		"# [3] pet-prj2\n10.0.1.1 dogs.example.org cats.example.org\nfd00::1 cats.example.org\n"
+
+	tests := []struct {
+		name  string
+		alias string
+		want  []string
+	}{
+		{"not found", "birds.example.org", []string{}},
+		{"both families", "localhost", []string{"ipv4 127.0.0.1 line 1 shadowed []", "ipv6 ::1 line 2 shadowed []"}},
+		{"first wins ignoring case and disabled", "CATS.example.org", []string{
+			"ipv4 10.0.0.1 line 6 shadowed [10.0.0.2 10.0.1.1]",
+			"ipv6 fd00::1 line 11 shadowed []",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			doc, _ := dom.Read(strings.NewReader(hosts))
+
+			// act
+			answers := Resolve(doc, tt.alias)
+
+			// assert
+			got := make([]string, 0)
+			for _, a := range answers {
+				shadowed := make([]string, 0)
+				for _, s := range a.Shadowed {
+					shadowed = append(shadowed, s.Entry.IP())
+				}
+				got = append(got, fmt.Sprintf("%s %s line %d shadowed %v", a.Family, a.Winner.Entry.IP(), a.Winner.Entry.Line(), shadowed))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,21 +1,50 @@
 package hosts
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"runtime"
 
 	"github.com/0xcfff/hostsctl/hosts/dom"
 	"github.com/spf13/afero"
 )
 
+// Defines how hosts file content is stored on disk
+type WriteMode int
+
+const (
+	WriteAtomic  WriteMode = iota        // write a temp file next to the hosts file and rename it over the original
+	WriteInPlace WriteMode = iota        // overwrite the hosts file content in place
+	WriteDefault           = WriteAtomic // default write mode (Atomic)
+)
+
+var (
+	ErrModifiedSinceLoad = errors.New("hosts file was modified by another program since it was loaded")
+
+	// Returned internally when hosts file can't be replaced by rename
+	// and in-place write should be used instead
+	errReplaceNotPossible = errors.New("hosts file can't be replaced atomically")
+)
+
 // Holds information about hosts mapping config file location
 type Source struct {
 	etcHostsPath string
 	fs           afero.Fs
+	writeMode    WriteMode
+	lockFile     *os.File
+	loadedHash   []byte
+	saveHook     SaveHook
+	dryRun       bool
 }
 
+// Function called after hosts file content is changed, receives the content before and after the change
+type SaveHook func(before []byte, after []byte) error
+
 var (
 	// Default OS-level hosts mapping configuration file
 	EtcHosts *Source = NewSource("", nil)
@@ -23,18 +52,16 @@ var (
 
 func NewSource(hostsFilePath string, fs afero.Fs) *Source {
 	fileSource := Source{}
-
 	if hostsFilePath == "" {
 		fileSource.etcHostsPath = defaultEtcHostsPath()
 	} else {
 		fileSource.etcHostsPath = hostsFilePath
 	}
-
 	fileSource.fs = fs
 	if fileSource.fs == nil {
 		fileSource.fs = afero.NewOsFs()
 	}
-
+	fileSource.writeMode = WriteDefault
 	return &fileSource
 }
 
@@ -42,6 +69,17 @@ func (src *Source) Path() string {
 	return src.etcHostsPath
 }
 
+func (src *Source) WriteMode() WriteMode {
+	return src.writeMode
+}
+
+// Sets the way the hosts file is written on Save.
+// Atomic mode falls back to in-place write when the file can't be
+// replaced by rename (e.g. bind-mounted /etc/hosts in a container)
+func (src *Source) SetWriteMode(mode WriteMode) {
+	src.writeMode = mode
+}
+
 func (src *Source) openRead() (afero.File, error) {
 	return src.fs.Open(src.etcHostsPath)
 }
@@ -56,28 +94,196 @@ func (src *Source) Load() (*dom.Document, error) {
 	if err != nil {
 		return nil, fmt.Errorf("can't open hosts file %s, %w", src.Path(), err)
 	}
-
 	defer f.Close()
 
-	doc, err := dom.Read(f)
+	h := sha256.New()
+	doc, err := dom.Read(io.TeeReader(f, h))
 	if err != nil {
 		return nil, fmt.Errorf("can't parse hosts file %s, %w", src.Path(), err)
 	}
+	src.loadedHash = h.Sum(nil)
 
 	return doc, nil
 }
 
 func (src *Source) Save(doc *dom.Document, fm dom.FmtMode) error {
+	buff := &bytes.Buffer{}
+	err := dom.Write(buff, doc, fm)
+	if err != nil {
+		return fmt.Errorf("can't format hosts file %s, %w", src.Path(), err)
+	}
+
+	return src.SaveRaw(buff.Bytes())
+}
+
+// Reads hosts file content as is, without parsing it
+func (src *Source) LoadRaw() ([]byte, error) {
+	data, err := afero.ReadFile(src.fs, src.etcHostsPath)
+	if err != nil {
+		return nil, fmt.Errorf("can't read hosts file %s, %w", src.Path(), err)
+	}
+	hash := sha256.Sum256(data)
+	src.loadedHash = hash[:]
+	return data, nil
+}
+
+// Replaces hosts file content with the data as is
+func (src *Source) SaveRaw(data []byte) error {
+	before, err := src.readCurrent()
+	if err != nil {
+		return err
+	}
+
+	err = src.checkNotModified(before)
+	if err != nil {
+		return err
+	}
+
+	if !src.dryRun {
+		err = src.write(data)
+		if err != nil {
+			return err
+		}
+		hash := sha256.Sum256(data)
+		src.loadedHash = hash[:]
+	}
+
+	if src.saveHook != nil && !bytes.Equal(before, data) {
+		err = src.saveHook(before, data)
+		if err != nil {
+			return fmt.Errorf("hosts file %s is saved, but post save action failed, %w", src.Path(), err)
+		}
+	}
+	return nil
+}
+
+// Sets function called after the hosts file content is changed by Save or SaveRaw
+func (src *Source) SetSaveHook(hook SaveHook) {
+	src.saveHook = hook
+}
+
+func (src *Source) DryRun() bool {
+	return src.dryRun
+}
+
+// Turns on mode in which Save and SaveRaw don't change the hosts file,
+// the save hook is still called, so it can report the changes
+func (src *Source) SetDryRun(dryRun bool) {
+	src.dryRun = dryRun
+}
+
+func (src *Source) write(data []byte) error {
+	if src.writeMode == WriteAtomic && !src.isSymlink() {
+		err := src.writeAtomic(data)
+		if !errors.Is(err, errReplaceNotPossible) {
+			return err
+		}
+	}
+
+	return src.writeInPlace(data)
+}
+
+// Returns current hosts file content, nil if the file does not exist
+func (src *Source) readCurrent() ([]byte, error) {
+	data, err := afero.ReadFile(src.fs, src.etcHostsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("can't read hosts file %s, %w", src.Path(), err)
+	}
+	return data, nil
+}
+
+// Verifies the hosts file content is the same as it was when the document was loaded
+func (src *Source) checkNotModified(current []byte) error {
+	if src.loadedHash == nil {
+		return nil
+	}
+
+	hash := sha256.Sum256(current)
+	if !bytes.Equal(src.loadedHash, hash[:]) {
+		return fmt.Errorf("%s; %w", src.Path(), ErrModifiedSinceLoad)
+	}
+	return nil
+}
+
+func (src *Source) Apply(handler func(path string, fs afero.Fs) error) error {
+	return handler(src.etcHostsPath, src.fs)
+}
+
+// Writes data to a temp file in the hosts file directory and renames it over the hosts file.
+// Returns errReplaceNotPossible if the file can't be replaced without losing its attributes
+func (src *Source) writeAtomic(data []byte) error {
+	path := src.etcHostsPath
+	dir := filepath.Dir(path)
+
+	info, err := src.fs.Stat(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("can't stat hosts file %s, %w", path, err)
+	}
+
+	tf, err := afero.TempFile(src.fs, dir, fmt.Sprintf(".%s.*.tmp", filepath.Base(path)))
+	if err != nil {
+		return fmt.Errorf("%w: %v", errReplaceNotPossible, err)
+	}
+	tmpPath := tf.Name()
+	committed := false
+	defer func() {
+		if !committed {
+			src.fs.Remove(tmpPath)
+		}
+	}()
+
+	_, err = tf.Write(data)
+	if err == nil {
+		err = tf.Sync()
+	}
+	if cerr := tf.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("can't write temp file %s, %w", tmpPath, err)
+	}
+
+	var mode os.FileMode = 0o644
+	if info != nil {
+		mode = info.Mode().Perm()
+	}
+	err = src.fs.Chmod(tmpPath, mode)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errReplaceNotPossible, err)
+	}
+
+	if info != nil {
+		err = copyFileAttributes(src.fs, info, path, tmpPath)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errReplaceNotPossible, err)
+		}
+	}
+
+	err = src.fs.Rename(tmpPath, path)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errReplaceNotPossible, err)
+	}
+	committed = true
+
+	syncDir(src.fs, dir)
+
+	return nil
+}
+
+// Overwrites hosts file content in place
+func (src *Source) writeInPlace(data []byte) error {
 	f, err := src.openWrite()
 	if err != nil {
 		return fmt.Errorf("can't open hosts file %s, %w", src.Path(), err)
 	}
-
 	defer f.Close()
 
-	err = dom.Write(f, doc, fm)
+	_, err = f.Write(data)
 	if err != nil {
-		return fmt.Errorf("can't parse hosts file %s, %w", src.Path(), err)
+		return fmt.Errorf("can't write hosts file %s, %w", src.Path(), err)
 	}
 
 	position, err := f.Seek(0, io.SeekCurrent)
@@ -90,11 +296,32 @@ func (src *Source) Save(doc *dom.Document, fm dom.FmtMode) error {
 		return fmt.Errorf("can't truncate %s, %w", src.Path(), err)
 	}
 
+	err = f.Sync()
+	if err != nil {
+		return fmt.Errorf("can't sync %s, %w", src.Path(), err)
+	}
+
 	return nil
 }
 
-func (src *Source) Apply(handler func(path string, fs afero.Fs) error) error {
-	return handler(src.etcHostsPath, src.fs)
+// Returns true if hosts file is a symlink, renaming over it would replace the link itself
+func (src *Source) isSymlink() bool {
+	lstater, ok := src.fs.(afero.Lstater)
+	if !ok {
+		return false
+	}
+	info, _, err := lstater.LstatIfPossible(src.etcHostsPath)
+	return err == nil && info.Mode()&os.ModeSymlink != 0
+}
+
+// Flushes directory entry changes, errors are ignored as not all platforms support it
+func syncDir(fs afero.Fs, dir string) {
+	d, err := fs.Open(dir)
+	if err != nil {
+		return
+	}
+	defer d.Close()
+	d.Sync()
 }
 
 func defaultEtcHostsPath() string {
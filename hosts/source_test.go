@@ -0,0 +1,162 @@
+package hosts
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/0xcfff/hostsctl/hosts/dom"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// Filesystem which can't rename files, behaves like bind-mounted /etc/hosts
+type noRenameFs struct {
+	afero.Fs
+}
+
+func (fs *noRenameFs) Rename(oldname, newname string) error {
+	return errors.New("device or resource busy")
+}
+
+func TestSource_Save(t *testing.T) {
+	const content = "127.0.0.1 localhost\n::1 ip6-localhost\n"
+	const longContent = "127.0.0.1 localhost\n::1 ip6-localhost\n192.168.100.1 router # home router\n"
+
+	prepare := func(fs afero.Fs, path string, content string) {
+		afero.WriteFile(fs, path, []byte(content), 0o600)
+	}
+	load := func(t *testing.T, fs afero.Fs, content string) *dom.Document {
+		tmp := "/tmp/hosts.src"
+		afero.WriteFile(fs, tmp, []byte(content), 0o644)
+		doc, err := NewSource(tmp, fs).Load()
+		assert.NoError(t, err)
+		fs.Remove(tmp)
+		return doc
+	}
+	dirEntries := func(fs afero.Fs, dir string) []string {
+		names := make([]string, 0)
+		infos, _ := afero.ReadDir(fs, dir)
+		for _, i := range infos {
+			names = append(names, i.Name())
+		}
+		return names
+	}
+
+	t.Run("atomic - replaces file and keeps mode", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		prepare(fs, "/etc/hosts", longContent)
+		doc := load(t, fs, content)
+
+		err := NewSource("/etc/hosts", fs).Save(doc, dom.FmtKeep)
+
+		assert.NoError(t, err)
+		data, _ := afero.ReadFile(fs, "/etc/hosts")
+		assert.Equal(t, content, string(data))
+		info, _ := fs.Stat("/etc/hosts")
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+		assert.Equal(t, []string{"hosts"}, dirEntries(fs, "/etc"))
+	})
+	t.Run("atomic - creates missing file", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		fs.MkdirAll("/etc", 0o755)
+		doc := load(t, fs, content)
+
+		err := NewSource("/etc/hosts", fs).Save(doc, dom.FmtKeep)
+
+		assert.NoError(t, err)
+		data, _ := afero.ReadFile(fs, "/etc/hosts")
+		assert.Equal(t, content, string(data))
+		info, _ := fs.Stat("/etc/hosts")
+		assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+	})
+	t.Run("atomic - falls back to in place write if rename fails", func(t *testing.T) {
+		fs := &noRenameFs{afero.NewMemMapFs()}
+		prepare(fs, "/etc/hosts", longContent)
+		doc := load(t, fs, content)
+
+		err := NewSource("/etc/hosts", fs).Save(doc, dom.FmtKeep)
+
+		assert.NoError(t, err)
+		data, _ := afero.ReadFile(fs, "/etc/hosts")
+		assert.Equal(t, content, string(data))
+		assert.Equal(t, []string{"hosts"}, dirEntries(fs, "/etc"))
+	})
+	t.Run("in place - overwrites and truncates file", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		prepare(fs, "/etc/hosts", longContent)
+		doc := load(t, fs, content)
+
+		src := NewSource("/etc/hosts", fs)
+		src.SetWriteMode(WriteInPlace)
+		err := src.Save(doc, dom.FmtKeep)
+
+		assert.NoError(t, err)
+		data, _ := afero.ReadFile(fs, "/etc/hosts")
+		assert.Equal(t, content, string(data))
+	})
+	t.Run("modified since load - fails", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		prepare(fs, "/etc/hosts", content)
+		src := NewSource("/etc/hosts", fs)
+		doc, err := src.Load()
+		assert.NoError(t, err)
+		prepare(fs, "/etc/hosts", longContent)
+
+		err = src.Save(doc, dom.FmtKeep)
+
+		assert.ErrorIs(t, err, ErrModifiedSinceLoad)
+		data, _ := afero.ReadFile(fs, "/etc/hosts")
+		assert.Equal(t, longContent, string(data))
+	})
+	t.Run("saved twice - succeeds", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		prepare(fs, "/etc/hosts", longContent)
+		src := NewSource("/etc/hosts", fs)
+		doc, err := src.Load()
+		assert.NoError(t, err)
+
+		err = src.Save(doc, dom.FmtKeep)
+		assert.NoError(t, err)
+		err = src.Save(doc, dom.FmtReFormat)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestSource_SaveHook(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/etc/hosts", []byte("127.0.0.1 localhost\n"), 0o644)
+	src := NewSource("/etc/hosts", fs)
+	calls := make([]string, 0)
+	src.SetSaveHook(func(before []byte, after []byte) error {
+		calls = append(calls, string(before)+"->"+string(after))
+		return nil
+	})
+
+	err := src.SaveRaw([]byte("127.0.0.1 localhost\n"))
+	assert.NoError(t, err)
+	err = src.SaveRaw([]byte("::1 localhost\n"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"127.0.0.1 localhost\n->::1 localhost\n"}, calls)
+}
+
+func TestSource_DryRun(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/etc/hosts", []byte("127.0.0.1 localhost\n"), 0o644)
+	src := NewSource("/etc/hosts", fs)
+	src.SetDryRun(true)
+	calls := 0
+	src.SetSaveHook(func(before []byte, after []byte) error {
+		calls++
+		return nil
+	})
+
+	err := src.SaveRaw([]byte("::1 localhost\n"))
+	assert.NoError(t, err)
+
+	data, _ := afero.ReadFile(fs, "/etc/hosts")
+	assert.Equal(t, "127.0.0.1 localhost\n", string(data))
+	assert.Equal(t, 1, calls)
+}
@@ -103,6 +103,7 @@ type IPMappingLine struct {
 	ip          string
 	domainNames []string
 	commentText string
+	disabled    bool
 }
 
 func (*IPMappingLine) Type() ElementType {
@@ -121,9 +122,22 @@ func (el *IPMappingLine) CommentText() string {
 	return el.commentText
 }
 
+// Returns true if the mapping is commented out
+func (el *IPMappingLine) Disabled() bool {
+	return el.disabled
+}
+
+// Returns IP address prefixed with comment sign if the mapping is disabled
+func (el *IPMappingLine) ipText() string {
+	if el.disabled {
+		return "# " + el.ip
+	}
+	return el.ip
+}
+
 func (el *IPMappingLine) formatLine() string {
 	b := strings.Builder{}
-	b.WriteString(el.ip)
+	b.WriteString(el.ipText())
 	b.WriteString(" ")
 
 	idx := 0
@@ -144,3 +158,10 @@ func NewIPMappingLine(ip string, domainNames []string, comment string) *IPMappin
 		commentText: comment,
 	}
 }
+
+// Creates IP mapping line which is commented out
+func NewDisabledIPMappingLine(ip string, domainNames []string, comment string) *IPMappingLine {
+	el := NewIPMappingLine(ip, domainNames, comment)
+	el.disabled = true
+	return el
+}
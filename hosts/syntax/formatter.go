@@ -136,7 +136,7 @@ func calculateAliasesActualColumnWidths(line string, el *IPMappingLine, settings
 func calculateAliasesTheoreticalColumnWidths(el *IPMappingLine, settings *aliasAutoformattingSettings) *aliasColumnsWidths {
 
 	cols := newAliasColumnWidths()
-	cols.ip = len(el.ip)
+	cols.ip = len(el.ipText())
 	cols.comment = len(el.commentText)
 
 	first := true
@@ -166,7 +166,7 @@ func formatAlias(el *IPMappingLine, fm FormatMode, fs *aliasAutoformattingSettin
 		if fp.ipPosition > 0 {
 			b.WriteString(strings.Repeat(" ", fp.ipPosition))
 		}
-		b.WriteString(el.ip)
+		b.WriteString(el.ipText())
 		if fp.aliasPosition > b.Len() {
 			b.WriteString(strings.Repeat(" ", fp.aliasPosition-b.Len()))
 		}
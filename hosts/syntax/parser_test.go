@@ -147,6 +147,47 @@ func Test_parse(t *testing.T) {
 		assert.Equal(t, ":t:1     ip6-localhost", *el0.preformattedLineText)
 	})
 
+	t.Run("ipv4 out of range file", func(t *testing.T) {
+		content := []byte("999.999.999.999 localhost")
+		reader := bytes.NewReader(content)
+
+		doc, err := parse(reader)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, doc)
+		assert.Equal(t, 1, len(doc.Elements()))
+		assert.Equal(t, Unknown, doc.Elements()[0].Type())
+	})
+
+	t.Run("ipv4-mapped ipv6 file", func(t *testing.T) {
+		content := []byte("::ffff:1.2.3.4 mapped.local")
+		reader := bytes.NewReader(content)
+
+		doc, err := parse(reader)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, doc)
+		assert.Equal(t, 1, len(doc.Elements()))
+		assert.Equal(t, IPMapping, doc.Elements()[0].Type())
+		el0 := doc.Elements()[0].(*IPMappingLine)
+		assert.Equal(t, "::ffff:1.2.3.4", el0.IPAddress())
+	})
+
+	t.Run("ipv6 with zone file", func(t *testing.T) {
+		content := []byte("fe80::1%eth0 router.local")
+		reader := bytes.NewReader(content)
+
+		doc, err := parse(reader)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, doc)
+		assert.Equal(t, 1, len(doc.Elements()))
+		assert.Equal(t, IPMapping, doc.Elements()[0].Type())
+		el0 := doc.Elements()[0].(*IPMappingLine)
+		assert.Equal(t, "fe80::1%eth0", el0.IPAddress())
+		assert.Equal(t, "router.local", el0.DomainNames()[0])
+	})
+
 	t.Run("all elements", func(t *testing.T) {
 		content := []byte(`# ipv4 mappings  
  127.0.0.1    localhost  
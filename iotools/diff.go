@@ -0,0 +1,192 @@
+package iotools
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Number of unchanged lines printed around changes in unified diff
+const DiffContextLines = 3
+
+type diffOp int
+
+const (
+	diffEqual  diffOp = iota
+	diffDelete diffOp = iota
+	diffInsert diffOp = iota
+)
+
+type diffEdit struct {
+	op   diffOp
+	a, b int // line indexes in the old and the new text
+}
+
+// Returns unified diff of the two texts, empty string if the texts are equal
+func UnifiedDiff(aName string, a []byte, bName string, b []byte) string {
+	al := splitDiffLines(string(a))
+	bl := splitDiffLines(string(b))
+	edits := diffLines(al, bl)
+
+	sb := &strings.Builder{}
+	for _, h := range diffHunks(edits, DiffContextLines) {
+		if sb.Len() == 0 {
+			fmt.Fprintf(sb, "--- %s\n+++ %s\n", aName, bName)
+		}
+		writeDiffHunk(sb, h, al, bl)
+	}
+	return sb.String()
+}
+
+func splitDiffLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Finds the shortest edit script using Myers algorithm.
+// Only the diagonals reachable on a step are kept in the trace, so memory grows with
+// the number of differences rather than with the size of the texts times the differences
+func diffLines(a, b []string) []diffEdit {
+	n, m := len(a), len(b)
+	max := n + m
+	off := max + 1
+	v := make([]int, 2*max+3)
+	trace := make([][]int, 0)
+
+	found := false
+	for d := 0; d <= max && !found; d++ {
+		// diagonals -d-1..d+1 are read when the path is restored
+		trace = append(trace, append([]int(nil), v[off-d-1:off+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[off+k-1] < v[off+k+1]) {
+				x = v[off+k+1]
+			} else {
+				x = v[off+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[off+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+
+	edits := make([]diffEdit, 0, max)
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		tv := trace[d]
+		toff := d + 1
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && tv[toff+k-1] < tv[toff+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := tv[toff+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			edits = append(edits, diffEdit{diffEqual, x - 1, y - 1})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, diffEdit{diffInsert, x, y - 1})
+			} else {
+				edits = append(edits, diffEdit{diffDelete, x - 1, y})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// Groups edits into hunks surrounded by context lines
+func diffHunks(edits []diffEdit, context int) [][]diffEdit {
+	hunks := make([][]diffEdit, 0)
+	start, end := -1, -1
+	for i, e := range edits {
+		if e.op == diffEqual {
+			continue
+		}
+		from := i - context
+		if from < 0 {
+			from = 0
+		}
+		to := i + context + 1
+		if to > len(edits) {
+			to = len(edits)
+		}
+		if start >= 0 && from > end {
+			hunks = append(hunks, edits[start:end])
+			start = -1
+		}
+		if start < 0 {
+			start = from
+		}
+		end = to
+	}
+	if start >= 0 {
+		hunks = append(hunks, edits[start:end])
+	}
+	return hunks
+}
+
+func writeDiffHunk(sb *strings.Builder, hunk []diffEdit, a, b []string) {
+	aStart, bStart := hunk[0].a, hunk[0].b
+	aCount, bCount := 0, 0
+	for _, e := range hunk {
+		switch e.op {
+		case diffEqual:
+			aCount++
+			bCount++
+		case diffDelete:
+			aCount++
+		case diffInsert:
+			bCount++
+		}
+	}
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(aStart, aCount), hunkRange(bStart, bCount))
+
+	for _, e := range hunk {
+		switch e.op {
+		case diffEqual:
+			writeDiffLine(sb, " ", a[e.a])
+		case diffDelete:
+			writeDiffLine(sb, "-", a[e.a])
+		case diffInsert:
+			writeDiffLine(sb, "+", b[e.b])
+		}
+	}
+}
+
+func hunkRange(start int, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprint(start + 1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func writeDiffLine(sb *strings.Builder, prefix string, line string) {
+	sb.WriteString(prefix)
+	sb.WriteString(line)
+	if !strings.HasSuffix(line, "\n") {
+		sb.WriteString("\n\\ No newline at end of file\n")
+	}
+}
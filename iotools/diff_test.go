@@ -0,0 +1,67 @@
+package iotools
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want string
+	}{
+		{"equal", "a\nb\n", "a\nb\n", ""},
+		{"both empty", "", "", ""},
+		{"from empty", "", "a\nb\n", "--- a\n+++ b\n@@ -0,0 +1,2 @@\n+a\n+b\n"},
+		{"to empty", "a\n", "", "--- a\n+++ b\n@@ -1 +0,0 @@\n-a\n"},
+		{"changed line", "1\n2\n3\n4\n5\n6\n7\n8\n9\n", "1\n2\n3\n4\nX\n6\n7\n8\n9\n",
+			"--- a\n+++ b\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+X\n 6\n 7\n 8\n"},
+		{"two hunks", "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n", "0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			"--- a\n+++ b\n@@ -1,3 +1,4 @@\n+0\n 1\n 2\n 3\n@@ -7,4 +8,3 @@\n 7\n 8\n 9\n-10\n"},
+		{"no newline at end", "a\nb", "a\nb\n", "--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UnifiedDiff("a", []byte(tt.a), "b", []byte(tt.b))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiffLines_ManyChanges(t *testing.T) {
+	a := make([]string, 0)
+	b := make([]string, 0)
+	for i := 0; i < 1000; i++ {
+		a = append(a, fmt.Sprintf("%d\n", i))
+		switch i % 3 {
+		case 0:
+			b = append(b, fmt.Sprintf("changed %d\n", i))
+		case 1:
+			b = append(b, fmt.Sprintf("%d\n", i), fmt.Sprintf("inserted %d\n", i))
+		}
+	}
+
+	edits := diffLines(a, b)
+
+	restored := make([]string, 0)
+	changes := 0
+	for _, e := range edits {
+		switch e.op {
+		case diffEqual:
+			assert.Equal(t, a[e.a], b[e.b])
+			restored = append(restored, a[e.a])
+		case diffInsert:
+			restored = append(restored, b[e.b])
+			changes++
+		case diffDelete:
+			changes++
+		}
+	}
+	assert.Equal(t, b, restored)
+	// every third line is replaced, every third removed and every third followed by an insert
+	assert.Equal(t, 334*2+333+333, changes)
+}
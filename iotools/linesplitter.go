@@ -12,7 +12,7 @@ func LinesSplitterRespectEndNewLineFunc() bufio.SplitFunc {
 		advance, token, err = bufio.ScanLines(data, atEOF)
 		if atEOF && advance == 0 && token == nil && hadCr {
 			hadCr = false
-			return 0, nil, bufio.ErrFinalToken
+			return 0, []byte{}, bufio.ErrFinalToken
 		}
 		hadCr = len(token) != advance
 		return advance, token, err
@@ -1,36 +1,157 @@
 package iptools
 
 import (
-	"net"
+	"fmt"
+	"net/netip"
+	"os"
+	"runtime"
 	"strings"
+	"sync"
+)
+
+// Origin of a system alias, tells why the alias is considered system
+type SystemAliasSource string
 
-	"golang.org/x/exp/slices"
+const (
+	SrcCommon   SystemAliasSource = "common"
+	SrcDebian   SystemAliasSource = "debian"
+	SrcRedHat   SystemAliasSource = "redhat"
+	SrcMacOS    SystemAliasSource = "macos"
+	SrcHostname SystemAliasSource = "hostname"
+	SrcUser     SystemAliasSource = "user"
 )
 
+// Alias created by the operating system, it should not be changed without confirmation
+type SystemAlias struct {
+	IP     netip.Addr
+	Alias  string
+	Source SystemAliasSource
+}
+
+// Set of system aliases, the first added alias wins if the same alias is added more than once
+type SystemAliasCatalog struct {
+	aliases []SystemAlias
+}
+
 var (
-	systemAliases = []struct {
-		ip    net.IP
-		alias string
-	}{
-		{net.IPv4(127, 0, 0, 1), "localhost"},
-		{net.ParseIP("::1"), "ip6-localhost"},
-		{net.ParseIP("::1"), "ip6-loopback"},
+	commonSystemAliases = []SystemAlias{
+		{netip.MustParseAddr("127.0.0.1"), "localhost", SrcCommon},
+		{netip.MustParseAddr("::1"), "localhost", SrcCommon},
+		{netip.MustParseAddr("::1"), "ip6-localhost", SrcCommon},
+		{netip.MustParseAddr("::1"), "ip6-loopback", SrcCommon},
 	}
-)
 
-func IsSystemAlias(ip string, alias string) bool {
-	ipTrimmed := strings.TrimSpace(ip)
-	aliasTrimmed := strings.ToLower(strings.TrimSpace(alias))
+	platformSystemAliases = map[string][]SystemAlias{
+		"linux": {
+			{netip.MustParseAddr("fe00::0"), "ip6-localnet", SrcDebian},
+			{netip.MustParseAddr("ff00::0"), "ip6-mcastprefix", SrcDebian},
+			{netip.MustParseAddr("ff02::1"), "ip6-allnodes", SrcDebian},
+			{netip.MustParseAddr("ff02::2"), "ip6-allrouters", SrcDebian},
+			{netip.MustParseAddr("ff02::3"), "ip6-allhosts", SrcDebian},
+			{netip.MustParseAddr("127.0.0.1"), "localhost.localdomain", SrcRedHat},
+			{netip.MustParseAddr("127.0.0.1"), "localhost4", SrcRedHat},
+			{netip.MustParseAddr("127.0.0.1"), "localhost4.localdomain4", SrcRedHat},
+			{netip.MustParseAddr("::1"), "localhost.localdomain", SrcRedHat},
+			{netip.MustParseAddr("::1"), "localhost6", SrcRedHat},
+			{netip.MustParseAddr("::1"), "localhost6.localdomain6", SrcRedHat},
+		},
+		"darwin": {
+			{netip.MustParseAddr("255.255.255.255"), "broadcasthost", SrcMacOS},
+		},
+	}
 
-	if IsIP(ipTrimmed) {
-		ip := net.ParseIP(ipTrimmed)
+	// loopback addresses the machine hostname is usually mapped to
+	hostnameAddrs = []netip.Addr{
+		netip.MustParseAddr("127.0.1.1"),
+		netip.MustParseAddr("127.0.0.1"),
+		netip.MustParseAddr("::1"),
+	}
+
+	defaultCatalog     *SystemAliasCatalog
+	defaultCatalogOnce sync.Once
+)
 
-		for _, p := range systemAliases {
-			if slices.Compare(p.ip, ip) == 0 && p.alias == aliasTrimmed {
-				return true
+// Creates catalog with system aliases of the platform (GOOS value),
+// aliases of the machine hostname are added if the hostname is not empty
+func NewSystemAliasCatalog(platform string, hostname string) *SystemAliasCatalog {
+	c := &SystemAliasCatalog{}
+	c.aliases = append(c.aliases, commonSystemAliases...)
+	c.aliases = append(c.aliases, platformSystemAliases[platform]...)
+
+	hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+	if hostname != "" {
+		names := []string{hostname}
+		if short, _, found := strings.Cut(hostname, "."); found {
+			names = append(names, short)
+		}
+		for _, ip := range hostnameAddrs {
+			for _, name := range names {
+				c.aliases = append(c.aliases, SystemAlias{ip, name, SrcHostname})
 			}
 		}
 	}
+	return c
+}
+
+// Returns catalog for the current platform and machine hostname
+func DefaultSystemAliases() *SystemAliasCatalog {
+	defaultCatalogOnce.Do(func() {
+		hostname, _ := os.Hostname()
+		defaultCatalog = NewSystemAliasCatalog(runtime.GOOS, hostname)
+	})
+	return defaultCatalog
+}
 
-	return false
+// Returns copy of the catalog, so aliases can be added without changing the original
+func (c *SystemAliasCatalog) Clone() *SystemAliasCatalog {
+	return &SystemAliasCatalog{
+		aliases: append([]SystemAlias(nil), c.aliases...),
+	}
+}
+
+// Adds alias to the catalog
+func (c *SystemAliasCatalog) Add(ip string, alias string, source SystemAliasSource) error {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return fmt.Errorf("%s is not an IP; %w", ip, err)
+	}
+	alias = strings.ToLower(strings.TrimSpace(alias))
+	if !IsHostname(alias) {
+		return fmt.Errorf("%s; %w", alias, ErrInvalidHostname)
+	}
+	c.aliases = append(c.aliases, SystemAlias{addr.Unmap(), alias, source})
+	return nil
+}
+
+// Returns all aliases of the catalog
+func (c *SystemAliasCatalog) Aliases() []SystemAlias {
+	return append([]SystemAlias(nil), c.aliases...)
+}
+
+// Returns system alias matching the IP and alias, IPv4-mapped IPv6 addresses are treated as IPv4
+func (c *SystemAliasCatalog) Lookup(ip string, alias string) (SystemAlias, bool) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return SystemAlias{}, false
+	}
+	addr = addr.Unmap()
+	aliasTrimmed := strings.ToLower(strings.TrimSpace(alias))
+
+	for _, a := range c.aliases {
+		if a.IP == addr && a.Alias == aliasTrimmed {
+			return a, true
+		}
+	}
+	return SystemAlias{}, false
+}
+
+// Returns true if the alias is in the catalog
+func (c *SystemAliasCatalog) IsSystemAlias(ip string, alias string) bool {
+	_, ok := c.Lookup(ip, alias)
+	return ok
+}
+
+// Returns true if the alias is system for the current platform and machine hostname
+func IsSystemAlias(ip string, alias string) bool {
+	return DefaultSystemAliases().IsSystemAlias(ip, alias)
 }
@@ -19,6 +19,8 @@ func TestIsSystemAlias(t *testing.T) {
 		{"public to custom", args{"55.03.04.99", "custom"}, false},
 		{"::1 to ipv6-localhost", args{"::1", "ip6-localhost"}, true},
 		{"::01 to ipv6-localhost", args{"::01", "ip6-localhost"}, true},
+		{"ipv4-mapped localhost", args{"::ffff:127.0.0.1", "localhost"}, true},
+		{"999.0.0.1 localhost", args{"999.0.0.1", "localhost"}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -28,3 +30,69 @@ func TestIsSystemAlias(t *testing.T) {
 		})
 	}
 }
+
+func TestSystemAliasCatalog_Lookup(t *testing.T) {
+	type args struct {
+		platform string
+		hostname string
+		ip       string
+		alias    string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		want   SystemAliasSource
+		wantOk bool
+	}{
+		{"common on any platform", args{"windows", "", "127.0.0.1", "localhost"}, SrcCommon, true},
+		{"ipv6 localhost", args{"darwin", "", "::1", "localhost"}, SrcCommon, true},
+		{"debian on linux", args{"linux", "", "ff02::1", "ip6-allnodes"}, SrcDebian, true},
+		{"debian not on macos", args{"darwin", "", "ff02::1", "ip6-allnodes"}, "", false},
+		{"redhat on linux", args{"linux", "", "::1", "localhost6.localdomain6"}, SrcRedHat, true},
+		{"broadcasthost on macos", args{"darwin", "", "255.255.255.255", "broadcasthost"}, SrcMacOS, true},
+		{"broadcasthost not on linux", args{"linux", "", "255.255.255.255", "broadcasthost"}, "", false},
+		{"hostname", args{"linux", "Laptop", "127.0.1.1", "laptop"}, SrcHostname, true},
+		{"short hostname", args{"linux", "laptop.home.", "127.0.1.1", "laptop"}, SrcHostname, true},
+		{"fqdn hostname", args{"linux", "laptop.home", "::1", "laptop.home"}, SrcHostname, true},
+		{"hostname to other ip", args{"linux", "laptop", "10.0.0.1", "laptop"}, "", false},
+		{"ipv4-mapped", args{"linux", "", "::ffff:127.0.0.1", "localhost"}, SrcCommon, true},
+		{"different notation", args{"linux", "", "fe00::", "ip6-localnet"}, SrcDebian, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewSystemAliasCatalog(tt.args.platform, tt.args.hostname)
+			got, ok := c.Lookup(tt.args.ip, tt.args.alias)
+			if ok != tt.wantOk || got.Source != tt.want {
+				t.Errorf("Lookup() = %v, %v, want %v, %v", got.Source, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestSystemAliasCatalog_Add(t *testing.T) {
+	c := NewSystemAliasCatalog("linux", "")
+	clone := c.Clone()
+
+	if err := clone.Add("10.8.0.1", "VPN-Gateway", SrcUser); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := clone.Add("10.8.0.999", "vpn-gateway", SrcUser); err == nil {
+		t.Errorf("Add() expected error for invalid IP")
+	}
+	if err := clone.Add("10.8.0.1", "vpn_gateway", SrcUser); err == nil {
+		t.Errorf("Add() expected error for invalid alias")
+	}
+	if sa, ok := clone.Lookup("10.8.0.1", "vpn-gateway"); !ok || sa.Source != SrcUser {
+		t.Errorf("Lookup() = %v, %v, want user alias", sa, ok)
+	}
+	if c.IsSystemAlias("10.8.0.1", "vpn-gateway") {
+		t.Errorf("IsSystemAlias() alias added to the clone is found in the original catalog")
+	}
+	// the first added alias wins
+	if err := clone.Add("127.0.0.1", "localhost", SrcUser); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if sa, _ := clone.Lookup("127.0.0.1", "localhost"); sa.Source != SrcCommon {
+		t.Errorf("Lookup() source = %v, want %v", sa.Source, SrcCommon)
+	}
+}
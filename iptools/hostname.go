@@ -0,0 +1,127 @@
+package iptools
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxHostnameLength = 253
+	maxLabelLength    = 63
+	acePrefix         = "xn--"
+)
+
+var (
+	ErrInvalidHostname = errors.New("invalid host name")
+)
+
+// Returns true if specified value is a valid host name according to RFC 1123 and RFC 952,
+// a trailing dot of fully qualified names is allowed. Internationalized names are valid
+// if their ASCII (punycode) form is valid
+func IsHostname(value string) bool {
+	_, err := ToASCIIHostname(value)
+	return err == nil
+}
+
+// Converts internationalized host name to ASCII, labels with non ASCII characters
+// are lower cased and encoded with punycode (bücher.example -> xn--bcher-kva.example).
+// Returns error if the result is not a valid host name
+func ToASCIIHostname(value string) (string, error) {
+	name, dot := strings.CutSuffix(value, ".")
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		ascii, err := toASCIILabel(label)
+		if err != nil {
+			return "", fmt.Errorf("%s: %v; %w", value, err, ErrInvalidHostname)
+		}
+		labels[i] = ascii
+	}
+	result := strings.Join(labels, ".")
+	if len(result) == 0 || len(result) > maxHostnameLength {
+		return "", fmt.Errorf("%s: length must be from 1 to %d characters; %w", value, maxHostnameLength, ErrInvalidHostname)
+	}
+	if isNumeric(labels[len(labels)-1]) {
+		// RFC 1123 2.1, names must not look like dotted-decimal addresses
+		return "", fmt.Errorf("%s: top level label must not be numeric; %w", value, ErrInvalidHostname)
+	}
+	if dot {
+		result += "."
+	}
+	return result, nil
+}
+
+// Converts punycode labels of the host name to unicode (xn--bcher-kva.example -> bücher.example)
+func ToUnicodeHostname(value string) (string, error) {
+	ascii, err := ToASCIIHostname(value)
+	if err != nil {
+		return "", err
+	}
+	labels := strings.Split(ascii, ".")
+	for i, label := range labels {
+		if hasACEPrefix(label) {
+			// validated by ToASCIIHostname
+			labels[i], _ = punycodeDecode(label[len(acePrefix):])
+		}
+	}
+	return strings.Join(labels, "."), nil
+}
+
+func toASCIILabel(label string) (string, error) {
+	if !isASCII(label) {
+		encoded, err := punycodeEncode(strings.ToLower(label))
+		if err != nil {
+			return "", err
+		}
+		label = acePrefix + encoded
+	} else if hasACEPrefix(label) {
+		decoded, err := punycodeDecode(label[len(acePrefix):])
+		if err != nil || isASCII(decoded) {
+			return "", fmt.Errorf("label %s is not a valid punycode", label)
+		}
+	}
+	if !isHostnameLabel(label) {
+		return "", fmt.Errorf("label %s is not valid", label)
+	}
+	return label, nil
+}
+
+func isHostnameLabel(label string) bool {
+	if len(label) == 0 || len(label) > maxLabelLength {
+		return false
+	}
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for _, c := range label {
+		isLetter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		isDigit := c >= '0' && c <= '9'
+		if !isLetter && !isDigit && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+func hasACEPrefix(label string) bool {
+	return len(label) > len(acePrefix) && strings.EqualFold(label[:len(acePrefix)], acePrefix)
+}
+
+func isASCII(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func isNumeric(value string) bool {
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return len(value) > 0
+}
@@ -0,0 +1,106 @@
+package iptools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsHostname(t *testing.T) {
+	type args struct {
+		value string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{"single label", args{"localhost"}, true},
+		{"fqdn", args{"api.example.com"}, true},
+		{"fqdn with trailing dot", args{"api.example.com."}, true},
+		{"leading digit", args{"1password.com"}, true},
+		{"hyphen inside", args{"ip6-localhost"}, true},
+		{"mixed case", args{"My-Host.Example.org"}, true},
+		{"empty", args{""}, false},
+		{"dot only", args{"."}, false},
+		{"empty label", args{"api..example.com"}, false},
+		{"leading hyphen", args{"-api.example.com"}, false},
+		{"trailing hyphen", args{"api-.example.com"}, false},
+		{"underscore", args{"my_host.local"}, false},
+		{"wildcard", args{"*.example.com"}, false},
+		{"too long label", args{strings.Repeat("a", 64) + ".com"}, false},
+		{"too long name", args{strings.Repeat("abcdefghi.", 26) + "com"}, false},
+		{"numeric top level label", args{"10.0.0.1"}, false},
+		{"numeric single label", args{"999"}, false},
+		{"idn", args{"bücher.example"}, true},
+		{"punycode", args{"xn--bcher-kva.example"}, true},
+		{"wrong punycode", args{"xn--bcher-kva-.example"}, false},
+		{"ascii only punycode", args{"xn--abc-.example"}, false},
+		{"idn with invalid characters", args{"bü_cher.example"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsHostname(tt.args.value); got != tt.want {
+				t.Errorf("IsHostname() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToASCIIHostname(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"ascii", "api.example.com", "api.example.com", false},
+		{"ascii case preserved", "Api.Example.com.", "Api.Example.com.", false},
+		{"latin", "bücher.example", "xn--bcher-kva.example", false},
+		{"latin upper case", "Bücher.example", "xn--bcher-kva.example", false},
+		{"cyrillic", "пример.испытание", "xn--e1afmkfd.xn--80akhbyknj4f", false},
+		{"japanese", "例え.jp", "xn--r8jz45g.jp", false},
+		{"single character", "ü.example", "xn--tda.example", false},
+		{"already encoded", "xn--mnchen-3ya.de", "xn--mnchen-3ya.de", false},
+		{"invalid", "my_host.local", "", true},
+		{"empty label", "bücher..example", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToASCIIHostname(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ToASCIIHostname() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ToASCIIHostname() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToUnicodeHostname(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"ascii", "api.example.com", "api.example.com", false},
+		{"latin", "xn--bcher-kva.example", "bücher.example", false},
+		{"upper case prefix", "XN--mnchen-3ya.de", "münchen.de", false},
+		{"arabic", "xn--egbpdaj6bu4bxfgehfvwxn.example", "ليهمابتكلموشعربي؟.example", false},
+		{"unicode input", "пример.example", "пример.example", false},
+		{"bad digit", "xn--bcher-kv!.example", "", true},
+		{"truncated", "xn--bcher-k.example", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToUnicodeHostname(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ToUnicodeHostname() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ToUnicodeHostname() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
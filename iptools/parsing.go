@@ -1,24 +1,50 @@
 package iptools
 
-import "regexp"
-
-var (
-	rxIPv4Address = regexp.MustCompile(`^\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}$`)
-	rxIPv6Address = regexp.MustCompile(`^[\da-fA-F]{0,}:[\da-fA-F]{0,}:[\da-fA-F]{0,}(:[\da-fA-F]{0,}){0,5}$`)
+import (
+	"fmt"
+	"net/netip"
 )
 
-
 // Returns true if specified value is IPv4
 func IsIPv4(value string) bool {
-	return rxIPv4Address.MatchString(value)
+	addr, err := netip.ParseAddr(value)
+	return err == nil && addr.Is4()
 }
 
-// Returns true if specified value is IPv6
+// Returns true if specified value is IPv6, including IPv4-mapped addresses (::ffff:1.2.3.4)
+// and addresses with zone (fe80::1%eth0)
 func IsIPv6(value string) bool {
-	return rxIPv6Address.MatchString(value)
+	addr, err := netip.ParseAddr(value)
+	return err == nil && addr.Is6()
+}
+
+// Returns true if specified value is IPv4-mapped IPv6 address (::ffff:1.2.3.4)
+func IsIPv4Mapped(value string) bool {
+	addr, err := netip.ParseAddr(value)
+	return err == nil && addr.Is4In6()
 }
 
-// Returns true if specified value is IPv6
+// Returns true if specified value is IPv4 or IPv6
 func IsIP(value string) bool {
-	return rxIPv4Address.MatchString(value) || rxIPv6Address.MatchString(value)
+	_, err := netip.ParseAddr(value)
+	return err == nil
+}
+
+// Returns canonical form of the IP: IPv6 addresses are compressed and lower cased
+// according to RFC 5952, the zone is preserved
+func CanonicalIP(value string) (string, error) {
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return "", fmt.Errorf("%s is not an IP; %w", value, err)
+	}
+	return addr.String(), nil
+}
+
+// Returns canonical form of the IP, IPv4-mapped IPv6 addresses are converted to IPv4
+func UnmapIP(value string) (string, error) {
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return "", fmt.Errorf("%s is not an IP; %w", value, err)
+	}
+	return addr.Unmap().String(), nil
 }
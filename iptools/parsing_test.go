@@ -16,6 +16,9 @@ func TestIsIPv4(t *testing.T) {
 		{"valid ipv4", args{"127.0.0.1"}, true},
 		{"fail as ipv6", args{"684D:1111:222:3333:4444:5555:6:77"}, false},
 		{"random text", args{"mytext"}, false},
+		{"octet out of range", args{"999.999.999.999"}, false},
+		{"leading zeros", args{"127.0.0.01"}, false},
+		{"ipv4-mapped", args{"::ffff:1.2.3.4"}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -39,6 +42,10 @@ func TestIsIPv6(t *testing.T) {
 		{"valid ipv6", args{"684D:1111:222:3333:4444:5555:6:77"}, true},
 		{"valid short ipv6", args{"fe00::0"}, true},
 		{"random text", args{"mytext"}, false},
+		{"ipv4-mapped", args{"::ffff:1.2.3.4"}, true},
+		{"with zone", args{"fe80::1%eth0"}, true},
+		{"too many groups", args{"1:2:3:4:5:6:7:8:9"}, false},
+		{"wrong group", args{"fe80::12345"}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -71,3 +78,58 @@ func TestIsIP(t *testing.T) {
 		})
 	}
 }
+
+func TestIsIPv4Mapped(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{"mapped", "::ffff:1.2.3.4", true},
+		{"mapped hex", "::ffff:0102:0304", true},
+		{"ipv4", "1.2.3.4", false},
+		{"ipv6", "fe80::1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsIPv4Mapped(tt.value); got != tt.want {
+				t.Errorf("IsIPv4Mapped() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalIP(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		unmap   string
+		wantErr bool
+	}{
+		{"ipv4", "10.0.0.1", "10.0.0.1", "10.0.0.1", false},
+		{"ipv6 expanded", "FE80:0000:0000:0000:0000:0000:0000:0001", "fe80::1", "fe80::1", false},
+		{"ipv6 loopback", "0:0:0:0:0:0:0:1", "::1", "::1", false},
+		{"with zone", "FE80::1%eth0", "fe80::1%eth0", "fe80::1%eth0", false},
+		{"ipv4-mapped", "::FFFF:10.0.0.1", "::ffff:10.0.0.1", "10.0.0.1", false},
+		{"invalid", "999.1.1.1", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalIP(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CanonicalIP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CanonicalIP() = %v, want %v", got, tt.want)
+			}
+			got, err = UnmapIP(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmapIP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.unmap {
+				t.Errorf("UnmapIP() = %v, want %v", got, tt.unmap)
+			}
+		})
+	}
+}
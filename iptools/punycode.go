@@ -0,0 +1,182 @@
+package iptools
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Bootstring parameters for punycode, see RFC 3492
+const (
+	pcBase        = 36
+	pcTMin        = 1
+	pcTMax        = 26
+	pcSkew        = 38
+	pcDamp        = 700
+	pcInitialBias = 72
+	pcInitialN    = 128
+	pcDelimiter   = '-'
+)
+
+var (
+	errPunycodeInput    = errors.New("bad punycode input")
+	errPunycodeOverflow = errors.New("punycode overflow")
+)
+
+// Encodes unicode label to punycode, the ACE prefix is not added
+func punycodeEncode(label string) (string, error) {
+	runes := []rune(label)
+	out := make([]byte, 0, len(label)+8)
+	for _, r := range runes {
+		if r < utf8.RuneSelf {
+			out = append(out, byte(r))
+		}
+	}
+	basic := len(out)
+	handled := basic
+	if basic > 0 {
+		out = append(out, pcDelimiter)
+	}
+
+	n, delta, bias := pcInitialN, 0, pcInitialBias
+	for handled < len(runes) {
+		m := math.MaxInt32
+		for _, r := range runes {
+			if int(r) >= n && int(r) < m {
+				m = int(r)
+			}
+		}
+		if m-n > (math.MaxInt32-delta)/(handled+1) {
+			return "", errPunycodeOverflow
+		}
+		delta += (m - n) * (handled + 1)
+		n = m
+		for _, r := range runes {
+			if int(r) < n {
+				delta++
+			}
+			if int(r) != n {
+				continue
+			}
+			q := delta
+			for k := pcBase; ; k += pcBase {
+				t := punycodeThreshold(k, bias)
+				if q < t {
+					break
+				}
+				out = append(out, punycodeDigit(t+(q-t)%(pcBase-t)))
+				q = (q - t) / (pcBase - t)
+			}
+			out = append(out, punycodeDigit(q))
+			bias = punycodeAdapt(delta, handled+1, handled == basic)
+			delta = 0
+			handled++
+		}
+		delta++
+		n++
+	}
+	return string(out), nil
+}
+
+// Decodes punycode label to unicode, the ACE prefix must be removed in advance
+func punycodeDecode(encoded string) (string, error) {
+	output := make([]rune, 0, len(encoded))
+	if pos := strings.LastIndexByte(encoded, pcDelimiter); pos >= 0 {
+		for _, r := range encoded[:pos] {
+			if r >= utf8.RuneSelf {
+				return "", errPunycodeInput
+			}
+			output = append(output, r)
+		}
+		encoded = encoded[pos+1:]
+	}
+
+	n, i, bias := pcInitialN, 0, pcInitialBias
+	for pos := 0; pos < len(encoded); {
+		oldi, w := i, 1
+		for k := pcBase; ; k += pcBase {
+			if pos >= len(encoded) {
+				return "", errPunycodeInput
+			}
+			digit, ok := punycodeDigitValue(encoded[pos])
+			pos++
+			if !ok {
+				return "", errPunycodeInput
+			}
+			if digit > (math.MaxInt32-i)/w {
+				return "", errPunycodeOverflow
+			}
+			i += digit * w
+			t := punycodeThreshold(k, bias)
+			if digit < t {
+				break
+			}
+			if w > math.MaxInt32/(pcBase-t) {
+				return "", errPunycodeOverflow
+			}
+			w *= pcBase - t
+		}
+		count := len(output) + 1
+		bias = punycodeAdapt(i-oldi, count, oldi == 0)
+		if i/count > math.MaxInt32-n {
+			return "", errPunycodeOverflow
+		}
+		n += i / count
+		i %= count
+		if n > unicode.MaxRune || (n >= 0xd800 && n <= 0xdfff) {
+			return "", errPunycodeInput
+		}
+		output = append(output, 0)
+		copy(output[i+1:], output[i:])
+		output[i] = rune(n)
+		i++
+	}
+	return string(output), nil
+}
+
+func punycodeThreshold(k int, bias int) int {
+	switch {
+	case k <= bias:
+		return pcTMin
+	case k >= bias+pcTMax:
+		return pcTMax
+	default:
+		return k - bias
+	}
+}
+
+func punycodeAdapt(delta int, count int, first bool) int {
+	if first {
+		delta /= pcDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / count
+	k := 0
+	for delta > ((pcBase-pcTMin)*pcTMax)/2 {
+		delta /= pcBase - pcTMin
+		k += pcBase
+	}
+	return k + (pcBase-pcTMin+1)*delta/(delta+pcSkew)
+}
+
+func punycodeDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
+
+func punycodeDigitValue(c byte) (int, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c-'0') + 26, true
+	case c >= 'a' && c <= 'z':
+		return int(c - 'a'), true
+	case c >= 'A' && c <= 'Z':
+		return int(c - 'A'), true
+	}
+	return 0, false
+}
@@ -0,0 +1,143 @@
+package iptools
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	rxIPv4Address = regexp.MustCompile(`^\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}$`)
+)
+
+// Parses IP address, unlike netip.ParseAddr IPv4 octets may have leading zeros,
+// they are treated as decimal numbers
+func ParseAddr(value string) (netip.Addr, error) {
+	value = strings.TrimSpace(value)
+	addr, err := netip.ParseAddr(value)
+	if err == nil {
+		return addr, nil
+	}
+	if !rxIPv4Address.MatchString(value) {
+		return netip.Addr{}, err
+	}
+
+	var octets [4]byte
+	for i, part := range strings.Split(value, ".") {
+		v, convErr := strconv.Atoi(part)
+		if convErr != nil || v > 255 {
+			return netip.Addr{}, err
+		}
+		octets[i] = byte(v)
+	}
+	return netip.AddrFrom4(octets), nil
+}
+
+// Returns true if both values are IPs and they are equal regardless of the notation
+func EqualAddr(a string, b string) bool {
+	x, err := ParseAddr(a)
+	if err != nil {
+		return false
+	}
+	y, err := ParseAddr(b)
+	if err != nil {
+		return false
+	}
+	return x.Unmap() == y.Unmap()
+}
+
+// Returns true if both values are IPs of the same family, IPv4-mapped IPv6 addresses are IPv4
+func SameFamily(a string, b string) bool {
+	x, err := ParseAddr(a)
+	if err != nil {
+		return false
+	}
+	y, err := ParseAddr(b)
+	if err != nil {
+		return false
+	}
+	return x.Unmap().Is4() == y.Unmap().Is4()
+}
+
+// Inclusive range of IP addresses of the same family
+type AddrRange struct {
+	From netip.Addr
+	To   netip.Addr
+}
+
+// Parses single IP address, CIDR network (192.168.100.0/24)
+// or range of addresses (192.168.100.10-192.168.100.20)
+func ParseAddrRange(value string) (AddrRange, error) {
+	value = strings.TrimSpace(value)
+
+	if addr, bits, found := strings.Cut(value, "/"); found {
+		from, err := ParseAddr(addr)
+		if err != nil {
+			return AddrRange{}, fmt.Errorf("invalid network %s, %w", value, err)
+		}
+		n, err := strconv.Atoi(bits)
+		if err != nil {
+			return AddrRange{}, fmt.Errorf("invalid network %s, %w", value, err)
+		}
+		prefix, err := from.Unmap().WithZone("").Prefix(n)
+		if err != nil {
+			return AddrRange{}, fmt.Errorf("invalid network %s, %w", value, err)
+		}
+		return AddrRange{From: prefix.Masked().Addr(), To: lastAddr(prefix)}, nil
+	}
+
+	if first, last, found := strings.Cut(value, "-"); found {
+		from, err := ParseAddr(first)
+		if err != nil {
+			return AddrRange{}, fmt.Errorf("invalid range %s, %w", value, err)
+		}
+		to, err := ParseAddr(last)
+		if err != nil {
+			return AddrRange{}, fmt.Errorf("invalid range %s, %w", value, err)
+		}
+		r := AddrRange{From: from.Unmap().WithZone(""), To: to.Unmap().WithZone("")}
+		if r.From.BitLen() != r.To.BitLen() || r.From.Compare(r.To) > 0 {
+			return AddrRange{}, fmt.Errorf("invalid range %s", value)
+		}
+		return r, nil
+	}
+
+	addr, err := ParseAddr(value)
+	if err != nil {
+		return AddrRange{}, err
+	}
+	addr = addr.Unmap().WithZone("")
+	return AddrRange{From: addr, To: addr}, nil
+}
+
+// Returns true if the IP belongs to the range, IPv4-mapped IPv6 addresses are treated as IPv4
+func (r AddrRange) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap().WithZone("")
+	return addr.BitLen() == r.From.BitLen() && r.From.Compare(addr) <= 0 && addr.Compare(r.To) <= 0
+}
+
+// Returns true if the value is an IP which belongs to the range
+func (r AddrRange) ContainsString(value string) bool {
+	addr, err := ParseAddr(value)
+	return err == nil && r.Contains(addr)
+}
+
+func (r AddrRange) String() string {
+	if r.From == r.To {
+		return r.From.String()
+	}
+	return r.From.String() + "-" + r.To.String()
+}
+
+// Returns the last address of the network
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Masked().Addr()
+	bytes := addr.AsSlice()
+	for bit := prefix.Bits(); bit < addr.BitLen(); bit++ {
+		bytes[bit/8] |= 0x80 >> (bit % 8)
+	}
+	last, _ := netip.AddrFromSlice(bytes)
+	return last
+}
@@ -0,0 +1,101 @@
+package iptools
+
+import (
+	"testing"
+)
+
+func TestEqualAddr(t *testing.T) {
+	type args struct {
+		a string
+		b string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{"same", args{"127.0.0.1", "127.0.0.1"}, true},
+		{"leading zeros", args{"127.0.0.1", "127.000.000.001"}, true},
+		{"ipv6 full form", args{"::1", "0:0:0:0:0:0:0:1"}, true},
+		{"ipv6 case", args{"FE80::1", "fe80::1"}, true},
+		{"ipv4-mapped", args{"::ffff:10.0.0.1", "10.0.0.1"}, true},
+		{"different", args{"10.0.0.1", "10.0.0.2"}, false},
+		{"octet out of range", args{"10.0.0.256", "10.0.0.0"}, false},
+		{"not an ip", args{"localhost", "localhost"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EqualAddr(tt.args.a, tt.args.b); got != tt.want {
+				t.Errorf("EqualAddr() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSameFamily(t *testing.T) {
+	type args struct {
+		a string
+		b string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{"ipv4", args{"10.0.0.1", "127.0.0.1"}, true},
+		{"ipv6", args{"fd00::1", "::1"}, true},
+		{"ipv4 and ipv6", args{"10.0.0.1", "fd00::1"}, false},
+		{"ipv4-mapped", args{"::ffff:10.0.0.1", "10.0.0.2"}, true},
+		{"not an ip", args{"localhost", "10.0.0.1"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SameFamily(tt.args.a, tt.args.b); got != tt.want {
+				t.Errorf("SameFamily() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddrRange_ContainsString(t *testing.T) {
+	type args struct {
+		query string
+		value string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{"single ip", args{"192.168.100.1", "192.168.100.001"}, true},
+		{"network first", args{"192.168.100.0/24", "192.168.100.0"}, true},
+		{"network last", args{"192.168.100.0/24", "192.168.100.255"}, true},
+		{"network outside", args{"192.168.100.0/24", "192.168.101.0"}, false},
+		{"network not masked", args{"192.168.100.7/24", "192.168.100.200"}, true},
+		{"ipv6 network", args{"fd00::/8", "fd12:3456::1"}, true},
+		{"ipv6 network other family", args{"::/0", "10.0.0.1"}, false},
+		{"ipv4-mapped in ipv4 network", args{"10.0.0.0/8", "::ffff:10.1.2.3"}, true},
+		{"range inside", args{"10.0.0.10-10.0.0.20", "10.0.0.20"}, true},
+		{"range outside", args{"10.0.0.10-10.0.0.20", "10.0.0.9"}, false},
+		{"not an ip", args{"10.0.0.0/8", "localhost"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseAddrRange(tt.args.query)
+			if err != nil {
+				t.Fatalf("ParseAddrRange() error = %v", err)
+			}
+			if got := r.ContainsString(tt.args.value); got != tt.want {
+				t.Errorf("ContainsString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAddrRange_errors(t *testing.T) {
+	for _, value := range []string{"", "localhost", "10.0.0.0/33", "10.0.0.0/x", "10.0.0.20-10.0.0.10", "10.0.0.1-::1", "pet-prj1"} {
+		if _, err := ParseAddrRange(value); err == nil {
+			t.Errorf("ParseAddrRange(%q) expected error", value)
+		}
+	}
+}